@@ -0,0 +1,167 @@
+package dec128
+
+import (
+	"sort"
+
+	"github.com/profe-ajedrez/badassitron/dec128/errors"
+	"github.com/profe-ajedrez/badassitron/dec128/uint128"
+)
+
+// Split splits the decimal into n parts with prec digits after the decimal point.
+// The parts always sum exactly to the decimal: the units left over by the integer division
+// are given, one each, to the first parts.
+// The decimal must be representable with prec digits, otherwise RescaleToLessPrecision is returned.
+//
+// Examples:
+//
+//	Split(100, 3, 2) = [33.34, 33.33, 33.33]
+//	Split(-0.05, 3, 2) = [-0.02, -0.02, -0.01]
+func (decimal Dec128) Split(n int, prec uint8) ([]Dec128, error) {
+	if n == 0 {
+		return nil, errors.DivisionByZero.Value()
+	}
+
+	if n < 0 {
+		return nil, errors.Negative.Value()
+	}
+
+	units, err := decimal.allocationUnits(prec)
+	if err != errors.None {
+		return nil, err.Value()
+	}
+
+	q, r, err := units.QuoRem64(uint64(n))
+	if err != errors.None {
+		return nil, err.Value()
+	}
+
+	parts := make([]Dec128, n)
+	for i := range parts {
+		coef := q
+		if uint64(i) < r {
+			coef, _ = coef.Add64(1)
+		}
+		parts[i] = Dec128{coef: coef, exp: prec, neg: decimal.neg && !coef.IsZero()}
+	}
+
+	return parts, nil
+}
+
+// Allocate splits the decimal in as many parts as weights, proportionally to them, with prec digits after the decimal point.
+// It uses the largest remainder method, so the parts always sum exactly to the decimal: after the
+// proportional shares are truncated, the units left over are given, one each, to the parts with the
+// largest remainders, the first ones winning ties.
+// Weights must not be negative and at least one must be positive.
+// The decimal must be representable with prec digits, otherwise RescaleToLessPrecision is returned.
+//
+// Examples:
+//
+//	Allocate(100, [1, 1, 1], 2) = [33.34, 33.33, 33.33]
+//	Allocate(10, [0.7, 0.2, 0.1], 0) = [7, 2, 1]
+//	Allocate(0.05, [3, 7], 2) = [0.02, 0.03]
+func (decimal Dec128) Allocate(weights []Dec128, prec uint8) ([]Dec128, error) {
+	if len(weights) == 0 {
+		return nil, errors.DivisionByZero.Value()
+	}
+
+	units, err := decimal.allocationUnits(prec)
+	if err != errors.None {
+		return nil, err.Value()
+	}
+
+	var wexp uint8
+	for _, w := range weights {
+		if w.err != errors.None {
+			return nil, w.err.Value()
+		}
+		if w.IsNegative() {
+			return nil, errors.Negative.Value()
+		}
+		wexp = max(wexp, w.exp)
+	}
+
+	coefs := make([]uint128.Uint128, len(weights))
+	var total uint128.Uint128
+	for i, w := range weights {
+		rw := w.Rescale(wexp)
+		if rw.err != errors.None {
+			return nil, rw.err.Value()
+		}
+		coefs[i] = rw.coef
+
+		total, err = total.Add(rw.coef)
+		if err != errors.None {
+			return nil, err.Value()
+		}
+	}
+
+	if total.IsZero() {
+		return nil, errors.DivisionByZero.Value()
+	}
+
+	parts := make([]Dec128, len(weights))
+	rems := make([]uint128.Uint128, len(weights))
+	left := units
+
+	for i, c := range coefs {
+		// share = units * weight / total, which can't exceed units because weight <= total
+		lo, carry := units.MulCarry(c)
+		q, r, err := uint128.QuoRem256By128(lo, carry, total)
+		if err != errors.None {
+			return nil, err.Value()
+		}
+
+		parts[i] = Dec128{coef: q, exp: prec, neg: decimal.neg && !q.IsZero()}
+		rems[i] = r
+		left = uint128.SubUnsafe(left, q)
+	}
+
+	if left.IsZero() {
+		return parts, nil
+	}
+
+	order := make([]int, len(parts))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		return rems[order[a]].Compare(rems[order[b]]) > 0
+	})
+
+	// the left over units are less than the number of parts with a remainder
+	for _, i := range order[:left.Lo] {
+		parts[i].coef, _ = parts[i].coef.Add64(1)
+		parts[i].neg = decimal.neg
+	}
+
+	return parts, nil
+}
+
+// allocationUnits returns the absolute value of the decimal as an integer amount of 10^-prec units.
+func (decimal Dec128) allocationUnits(prec uint8) (uint128.Uint128, errors.Error) {
+	if decimal.err != errors.None {
+		return uint128.Zero, decimal.err
+	}
+
+	if prec > MaxPrecision {
+		return uint128.Zero, errors.PrecisionOutOfRange
+	}
+
+	if decimal.exp > prec {
+		_, r, err := decimal.coef.QuoRem64(Pow10Uint64[decimal.exp-prec])
+		if err != errors.None {
+			return uint128.Zero, err
+		}
+		if r != 0 {
+			return uint128.Zero, errors.RescaleToLessPrecision
+		}
+	}
+
+	d := decimal.Rescale(prec)
+	if d.err != errors.None {
+		return uint128.Zero, d.err
+	}
+
+	return d.coef, errors.None
+}
@@ -14,7 +14,12 @@ func (decimal Dec128) Add(other Dec128) Dec128 {
 		return other
 	}
 
-	r, ok := decimal.tryAdd(other)
+	r, ok := decimal.tryAdd64(other)
+	if ok {
+		return r
+	}
+
+	r, ok = decimal.tryAdd(other)
 	if ok {
 		return r
 	}
@@ -48,7 +53,12 @@ func (decimal Dec128) Sub(other Dec128) Dec128 {
 		return other
 	}
 
-	r, ok := decimal.trySub(other)
+	r, ok := decimal.tryAdd64(other.Neg())
+	if ok {
+		return r
+	}
+
+	r, ok = decimal.trySub(other)
 	if ok {
 		return r
 	}
@@ -86,7 +96,12 @@ func (decimal Dec128) Mul(other Dec128) Dec128 {
 		return Zero
 	}
 
-	r, ok := decimal.tryMul(other)
+	r, ok := decimal.tryMul64(other)
+	if ok {
+		return r
+	}
+
+	r, ok = decimal.tryMul(other)
 	if ok {
 		return r
 	}
@@ -128,7 +143,12 @@ func (decimal Dec128) Div(other Dec128) Dec128 {
 		return Zero
 	}
 
-	r, ok := decimal.tryDiv(other)
+	r, ok := decimal.tryDiv64(other)
+	if ok {
+		return r
+	}
+
+	r, ok = decimal.tryDiv(other)
 	if ok {
 		return r
 	}
@@ -150,6 +170,93 @@ func (decimal Dec128) DivInt(other int) Dec128 {
 	return decimal.Div(FromInt(other))
 }
 
+// DivRound returns decimal / other rounded to prec digits after the decimal point using mode,
+// and whether the quotient had to be rounded.
+// If any of the Dec128 is NaN, the result will be NaN.
+// In case of overflow, division by zero, an unknown mode or a precision over MaxPrecision, the result will be NaN.
+//
+// Examples:
+//
+//	DivRound(2, 3, 2, RoundingHalfAwayFromZero) = 0.67, true
+//	DivRound(2, 3, 2, RoundingTowardZero) = 0.66, true
+//	DivRound(1, 4, 2, RoundingHalfEven) = 0.25, false
+func (decimal Dec128) DivRound(other Dec128, prec uint8, mode RoundingMode) (Dec128, bool) {
+	if decimal.err != errors.None {
+		return decimal, false
+	}
+
+	if other.err != errors.None {
+		return other, false
+	}
+
+	if prec > MaxPrecision {
+		return NaN(errors.PrecisionOutOfRange), false
+	}
+
+	if mode > RoundingAwayFromZero {
+		return NaN(errors.InvalidFormat), false
+	}
+
+	if other.IsZero() {
+		return NaN(errors.DivisionByZero), false
+	}
+
+	if decimal.IsZero() {
+		return Zero, false
+	}
+
+	r, inexact, ok := decimal.tryDivRound(other, prec, mode)
+	if ok {
+		return r, inexact
+	}
+
+	// the scaled operands don't fit in 128 bits, divide exactly
+	q := decimal.rat()
+	return roundRat(q.Quo(q, other.rat()), prec, mode)
+}
+
+// DivIntRound returns decimal / other rounded to prec digits after the decimal point using mode,
+// and whether the quotient had to be rounded.
+// If Dec128 is NaN, the result will be NaN.
+// In case of overflow, division by zero, an unknown mode or a precision over MaxPrecision, the result will be NaN.
+func (decimal Dec128) DivIntRound(other int, prec uint8, mode RoundingMode) (Dec128, bool) {
+	return decimal.DivRound(FromInt(other), prec, mode)
+}
+
+// QuoRemRound returns the quotient of decimal / other rounded to prec digits after the decimal point using mode,
+// and the remainder decimal - quotient * other, which is zero when the division is exact.
+// Unlike QuoRem, the quotient may have decimals, and the remainder has the opposite sign of decimal
+// when the quotient is rounded away from zero.
+// If any of the Dec128 is NaN, the result will be NaN.
+// In case of overflow, division by zero, an unknown mode or a precision over MaxPrecision, the result will be NaN.
+// If the remainder needs more than MaxPrecision digits after the decimal point, it will be NaN.
+//
+// Examples:
+//
+//	QuoRemRound(10, 3, 2, RoundingHalfAwayFromZero) = 3.33, 0.01
+//	QuoRemRound(20, 3, 2, RoundingHalfAwayFromZero) = 6.67, -0.01
+func (decimal Dec128) QuoRemRound(other Dec128, prec uint8, mode RoundingMode) (Dec128, Dec128) {
+	q, inexact := decimal.DivRound(other, prec, mode)
+	if q.err != errors.None {
+		return q, q
+	}
+
+	if !inexact {
+		return q, Zero
+	}
+
+	// the remainder is exact, with at most the decimals of decimal or of quotient * other
+	r := q.rat()
+	r.Sub(decimal.rat(), r.Mul(r, other.rat()))
+
+	rem, inexact := roundRat(r, min(max(decimal.exp, prec+other.exp), MaxPrecision), RoundingTowardZero)
+	if inexact {
+		return q, NaN(errors.PrecisionOutOfRange)
+	}
+
+	return q, rem
+}
+
 // Mod returns decimal % other.
 // If any of the Dec128 is NaN, the result will be NaN.
 // In case of overflow, underflow, or division by zero, the result will be NaN.
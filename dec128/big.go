@@ -0,0 +1,177 @@
+package dec128
+
+import (
+	"math/big"
+
+	"github.com/profe-ajedrez/badassitron/dec128/errors"
+	"github.com/profe-ajedrez/badassitron/dec128/uint128"
+)
+
+// BigRat returns the exact value of the decimal as a big.Rat.
+// If the Dec128 is NaN, it returns an error.
+func (decimal Dec128) BigRat() (*big.Rat, error) {
+	if decimal.err != errors.None {
+		return nil, decimal.err.Value()
+	}
+	return decimal.rat(), nil
+}
+
+// FromBigRat returns r rounded to prec digits after the decimal point using mode.
+// It returns NaN with NotANumber if r is nil, and with Overflow if the result does not fit.
+func FromBigRat(r *big.Rat, prec uint8, mode RoundingMode) Dec128 {
+	if r == nil {
+		return NaN(errors.NotANumber)
+	}
+	return ratToDec(r, prec, mode)
+}
+
+// BigFloat returns the decimal as a big.Float with prec bits of mantissa, rounded to nearest even.
+// If prec is 0, it uses 128 bits, so integer decimals are always exact.
+// If the Dec128 is NaN, it returns an error.
+func (decimal Dec128) BigFloat(prec uint) (*big.Float, error) {
+	if decimal.err != errors.None {
+		return nil, decimal.err.Value()
+	}
+
+	if prec == 0 {
+		prec = 128
+	}
+
+	return new(big.Float).SetPrec(prec).SetRat(decimal.rat()), nil
+}
+
+// FromBigFloat returns f rounded to prec digits after the decimal point using mode.
+// Infinities return NaN with Overflow (positive) or Underflow (negative), nil returns NaN with NotANumber,
+// and values that do not fit return NaN with Overflow.
+func FromBigFloat(f *big.Float, prec uint8, mode RoundingMode) Dec128 {
+	if f == nil {
+		return NaN(errors.NotANumber)
+	}
+
+	if f.IsInf() {
+		if f.Signbit() {
+			return NaN(errors.Underflow)
+		}
+		return NaN(errors.Overflow)
+	}
+
+	// the coefficient of a Dec128 is below 2^128, and values below 2^-200 round like any other value
+	// between zero and half a unit of the smallest precision, so huge exponents never reach big.Rat
+	exp := f.MantExp(nil)
+	if exp > 128 {
+		return NaN(errors.Overflow)
+	}
+
+	if exp < -200 {
+		tiny := new(big.Rat).SetFrac(big.NewInt(int64(f.Sign())), new(big.Int).Lsh(big.NewInt(1), 200))
+		return ratToDec(tiny, prec, mode)
+	}
+
+	r, _ := f.Rat(nil)
+	return ratToDec(r, prec, mode)
+}
+
+// EncodeToBigInt returns the Dec128 as a big.Int coefficient with requested exponent, that is, scaled by 10^exp.
+// Digits beyond exp are truncated, like Rescale does. Unlike EncodeToUint128, negative values are allowed
+// and the result may be larger than 128 bits.
+func (decimal Dec128) EncodeToBigInt(exp uint8) (*big.Int, error) {
+	if decimal.err != errors.None {
+		return nil, decimal.err.Value()
+	}
+
+	if exp > MaxPrecision {
+		return nil, errors.PrecisionOutOfRange.Value()
+	}
+
+	x := decimal.coef.BigInt()
+	if exp >= decimal.exp {
+		x.Mul(x, pow10Big(exp-decimal.exp))
+	} else {
+		x.Quo(x, pow10Big(decimal.exp-exp))
+	}
+
+	if decimal.neg {
+		x.Neg(x)
+	}
+
+	return x, nil
+}
+
+// DecodeFromBigInt decodes a Dec128 from a big.Int coefficient and an exponent, that is, coef / 10^exp.
+// It returns NaN with NotANumber if coef is nil, and with Overflow if coef does not fit in 128 bits.
+func DecodeFromBigInt(coef *big.Int, exp uint8) Dec128 {
+	if coef == nil {
+		return NaN(errors.NotANumber)
+	}
+
+	u, err := uint128.FromBigInt(new(big.Int).Abs(coef))
+	if err != errors.None {
+		return NaN(errors.Overflow)
+	}
+
+	return New(u, exp, coef.Sign() < 0)
+}
+
+// rat returns the decimal as a big.Rat. The decimal must not be NaN.
+func (decimal Dec128) rat() *big.Rat {
+	num := decimal.coef.BigInt()
+	if decimal.neg {
+		num.Neg(num)
+	}
+	den := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimal.exp)), nil)
+	return new(big.Rat).SetFrac(num, den)
+}
+
+// ratToDec rounds r to prec digits after the decimal point using mode.
+func ratToDec(r *big.Rat, prec uint8, mode RoundingMode) Dec128 {
+	d, _ := roundRat(r, prec, mode)
+	return d
+}
+
+// roundRat rounds r to prec digits after the decimal point using mode, and tells whether it was inexact.
+func roundRat(r *big.Rat, prec uint8, mode RoundingMode) (Dec128, bool) {
+	if prec > MaxPrecision {
+		return NaN(errors.PrecisionOutOfRange), false
+	}
+
+	if mode > RoundingAwayFromZero {
+		return NaN(errors.InvalidFormat), false
+	}
+
+	neg := r.Sign() < 0
+	num := new(big.Int).Abs(r.Num())
+	num.Mul(num, pow10Big(prec))
+
+	q, rem := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+	inexact := rem.Sign() != 0
+	half := rem.Lsh(rem, 1).Cmp(r.Denom())
+
+	d := roundedFromBig(q, inexact, half, neg, prec, mode)
+	return d, inexact && d.err == errors.None
+}
+
+// roundedFromBig builds the decimal from the truncated coefficient q, moving it one step away from zero
+// when mode says so for an inexact result, given how the discarded part compares with half a step.
+func roundedFromBig(q *big.Int, inexact bool, half int, neg bool, prec uint8, mode RoundingMode) Dec128 {
+	coef, err := uint128.FromBigInt(q)
+	if err != errors.None {
+		return NaN(errors.Overflow)
+	}
+
+	if inexact && roundsAwayFromZero(Dec128{coef: coef}, half, neg, mode) {
+		if coef, err = coef.Add64(1); err != errors.None {
+			return NaN(errors.Overflow)
+		}
+	}
+
+	if coef.IsZero() {
+		return Zero
+	}
+
+	return Dec128{coef: coef, exp: prec, neg: neg}
+}
+
+// pow10Big returns 10^prec as a big.Int, for prec up to MaxPrecision.
+func pow10Big(prec uint8) *big.Int {
+	return new(big.Int).SetUint64(Pow10Uint64[prec])
+}
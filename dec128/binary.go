@@ -0,0 +1,113 @@
+package dec128
+
+import (
+	"github.com/profe-ajedrez/badassitron/dec128/errors"
+	"github.com/profe-ajedrez/badassitron/dec128/uint128"
+)
+
+// BinaryVersion is the version of the binary encoding written by MarshalBinary and AppendBinary.
+const BinaryVersion = 1
+
+const (
+	binaryExpMask = 0x1f // bits 0-4 of the header hold the exponent
+	binaryNeg     = 0x20 // bit 5 of the header is set for negative values
+	binaryNaN     = 0x40 // bit 6 of the header is set for NaN, the next byte holds the reason
+)
+
+// AppendText implements the encoding.TextAppender interface.
+// It appends the same representation MarshalText returns.
+func (decimal Dec128) AppendText(b []byte) ([]byte, error) {
+	if decimal.err != errors.None {
+		return append(b, NaNStr...), nil
+	}
+
+	if decimal.IsZero() {
+		return append(b, ZeroStr...), nil
+	}
+
+	sb, trim := decimal.appendString(b)
+	if trim {
+		return trimTrailingZeros(sb), nil
+	}
+
+	return sb, nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+//
+// The encoding is compact and versioned:
+//
+//	byte 0:  version
+//	byte 1:  header, exponent in bits 0-4, bit 5 set if negative, bit 6 set if NaN
+//	byte 2+: the NaN reason if NaN, otherwise the coefficient as unsigned varint
+func (decimal Dec128) MarshalBinary() ([]byte, error) {
+	return decimal.AppendBinary(make([]byte, 0, 8))
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface.
+// It appends the same encoding MarshalBinary returns.
+func (decimal Dec128) AppendBinary(b []byte) ([]byte, error) {
+	if decimal.err != errors.None {
+		return append(b, BinaryVersion, binaryNaN, byte(decimal.err)), nil
+	}
+
+	header := decimal.exp
+	if decimal.neg && !decimal.coef.IsZero() {
+		header |= binaryNeg
+	}
+
+	b = append(b, BinaryVersion, header)
+	return decimal.coef.AppendUvarint(b), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (decimal *Dec128) UnmarshalBinary(b []byte) error {
+	if len(b) < 3 {
+		return errors.NotEnoughBytes.Value()
+	}
+
+	if b[0] != BinaryVersion {
+		return errors.InvalidFormat.Value()
+	}
+
+	header := b[1]
+
+	if header&binaryNaN != 0 {
+		if header != binaryNaN || len(b) != 3 || errors.Error(b[2]) == errors.None || !errors.Error(b[2]).Valid() {
+			return errors.InvalidFormat.Value()
+		}
+		*decimal = NaN(errors.Error(b[2]))
+		return nil
+	}
+
+	if header&^(binaryExpMask|binaryNeg) != 0 {
+		return errors.InvalidFormat.Value()
+	}
+
+	exp := header & binaryExpMask
+	if exp > MaxPrecision {
+		return errors.PrecisionOutOfRange.Value()
+	}
+
+	coef, n, err := uint128.FromUvarint(b[2:])
+	if err != errors.None {
+		return err.Value()
+	}
+
+	if n != len(b)-2 {
+		return errors.InvalidFormat.Value()
+	}
+
+	*decimal = Dec128{coef: coef, exp: exp, neg: header&binaryNeg != 0}
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+func (decimal Dec128) GobEncode() ([]byte, error) {
+	return decimal.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+func (decimal *Dec128) GobDecode(b []byte) error {
+	return decimal.UnmarshalBinary(b)
+}
@@ -49,6 +49,8 @@ var (
 	Pow10Uint128 = uint128.Pow10Uint128
 
 	defaultPrecision = MaxPrecision
+
+	digitSeparators = "_"
 )
 
 // SetDefaultPrecision sets the default precision for all Dec128 instances, where precision is the number of digits after the decimal point.
@@ -58,3 +60,20 @@ func SetDefaultPrecision(prec uint8) {
 	}
 	defaultPrecision = prec
 }
+
+// DefaultPrecision returns the default precision, the number of digits after the decimal point Div keeps at least.
+func DefaultPrecision() uint8 {
+	return defaultPrecision
+}
+
+// SetDigitSeparators sets the characters FromString accepts to group digits, e.g. "_," to read "1,000_000".
+// Separators can't be digits, signs, the decimal point or the exponent mark. An empty string disables them.
+func SetDigitSeparators(seps string) {
+	for i := range len(seps) {
+		switch c := seps[i]; {
+		case isDigit(c), c == '.', c == '+', c == '-', c == 'e', c == 'E':
+			panic(errors.InvalidFormat.Value())
+		}
+	}
+	digitSeparators = seps
+}
@@ -4,7 +4,6 @@ package dec128
 import (
 	"bytes"
 	"database/sql/driver"
-	"fmt"
 	"math"
 	"strconv"
 
@@ -280,13 +279,7 @@ func (decimal Dec128) MarshalText() ([]byte, error) {
 		return ZeroStrBytes, nil
 	}
 
-	buf := [MaxStrLen]byte{}
-	sb, trim := decimal.appendString(buf[:0])
-	if trim {
-		return trimTrailingZeros(sb), nil
-	}
-
-	return sb, nil
+	return decimal.AppendText(make([]byte, 0, MaxStrLen))
 }
 
 // UnmarshalText implements the encoding.TextUnmarshaler interface.
@@ -315,13 +308,19 @@ func (decimal Dec128) MarshalJSON() ([]byte, error) {
 		return ZeroJsonStrBytes, nil
 	}
 
-	buf := [MaxStrLen + 2]byte{}
-	buf[0] = '"'
-	sb, trim := decimal.appendString(buf[:1])
-	if trim {
-		sb = trimTrailingZeros(sb)
+	return decimal.AppendJSON(make([]byte, 0, MaxStrLen+2))
+}
+
+// AppendJSON appends the JSON representation of the decimal to b, the same MarshalJSON returns.
+// It doesn't allocate when b has enough capacity, MaxStrLen + 2 bytes are enough for any value.
+func (decimal Dec128) AppendJSON(b []byte) ([]byte, error) {
+	if decimal.err != errors.None {
+		return append(b, NaNJsonStrBytes...), nil
 	}
-	return append(sb, '"'), nil
+
+	b = append(b, '"')
+	b, _ = decimal.AppendText(b)
+	return append(b, '"'), nil
 }
 
 var nullValue = []byte("null")
@@ -347,33 +346,24 @@ func (decimal *Dec128) UnmarshalJSON(data []byte) error {
 }
 
 // Scan implements the sql.Scanner interface.
-func (decimal Dec128) Scan(src any) error {
-	var err error
-	switch v := src.(type) {
-	case string:
-		decimal = FromString(v)
-		if decimal.IsNaN() {
-			err = decimal.ErrorDetails()
-		}
-	case int:
-		decimal = FromInt(v)
-	case int64:
-		decimal = FromInt64(v)
-	case float32:
-		decimal = FromString(fmt.Sprintf("%f", v))
-	case float64:
-		decimal = FromFloat64(v)
-	case nil:
-		decimal = Zero
-	default:
-		err = fmt.Errorf("can't scan %T to Dec128: %T is not supported", src, src)
-	}
-
-	return err
+// It accepts the []byte and string values most drivers return for NUMERIC columns, integers and floats.
+// Values that can't be represented without losing precision are rejected.
+// NULL is rejected too, use NullDec128 for nullable columns.
+func (decimal *Dec128) Scan(src any) error {
+	d, err := scan(src)
+	if err != nil {
+		return err
+	}
+	*decimal = d
+	return nil
 }
 
 // Value implements the driver.Valuer interface.
+// NaN can't be stored, so its error is returned instead.
 func (decimal Dec128) Value() (driver.Value, error) {
+	if decimal.err != errors.None {
+		return nil, decimal.err.Value()
+	}
 	return decimal.String(), nil
 }
 
@@ -452,6 +442,70 @@ func (decimal Dec128) StringFixed() string {
 	return string(sb)
 }
 
+// StringSci returns the representation of the Dec128 in exponent notation, with one digit before the
+// decimal point and the trailing zeros removed, in the format strconv uses for 'e': "1.2345e+03", "-5e-07".
+// If the Dec128 is zero, the string "0e+00" is returned.
+// If the Dec128 is NaN, the string "NaN" is returned.
+func (decimal Dec128) StringSci() string {
+	buf := [MaxStrLen + 5]byte{}
+	return string(decimal.AppendStringSci(buf[:0]))
+}
+
+// AppendStringSci appends the representation StringSci returns to b.
+// It doesn't allocate when b has enough capacity, MaxStrLen + 5 bytes are enough for any value.
+func (decimal Dec128) AppendStringSci(b []byte) []byte {
+	if decimal.err != errors.None {
+		return append(b, NaNStr...)
+	}
+
+	if decimal.IsZero() {
+		return append(b, "0e+00"...)
+	}
+
+	buf := [uint128.MaxStrLen]byte{}
+	digits := decimal.coef.StringToBuf(buf[:])
+	e := len(digits) - 1 - int(decimal.exp)
+
+	for len(digits) > 1 && digits[len(digits)-1] == '0' {
+		digits = digits[:len(digits)-1]
+	}
+
+	if decimal.neg {
+		b = append(b, '-')
+	}
+
+	b = append(b, digits[0])
+	if len(digits) > 1 {
+		b = append(b, '.')
+		b = append(b, digits[1:]...)
+	}
+
+	b = append(b, 'e')
+	if e < 0 {
+		b = append(b, '-')
+		e = -e
+	} else {
+		b = append(b, '+')
+	}
+
+	return append(b, byte('0'+e/10), byte('0'+e%10))
+}
+
+// AppendStringFixed appends the representation StringFixed returns to b.
+// It doesn't allocate when b has enough capacity, MaxStrLen bytes are enough for any value.
+func (decimal Dec128) AppendStringFixed(b []byte) []byte {
+	if decimal.err != errors.None {
+		return append(b, NaNStr...)
+	}
+
+	if decimal.IsZero() {
+		return append(b, zeroStrs[decimal.exp]...)
+	}
+
+	sb, _ := decimal.appendString(b)
+	return sb
+}
+
 // Int returns the integer part of the Dec128 as int.
 func (decimal Dec128) Int() (int, error) {
 	t := decimal.Rescale(0)
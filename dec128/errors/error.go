@@ -17,6 +17,7 @@ const (
 	PrecisionOutOfRange
 	RescaleToLessPrecision
 	SqrtNegative
+	OutOfDomain
 )
 
 var code2err = [...]error{
@@ -31,6 +32,12 @@ var code2err = [...]error{
 	PrecisionOutOfRange:    errors.New("precision out of range"),
 	RescaleToLessPrecision: errors.New("rescale to less precision"),
 	SqrtNegative:           errors.New("square root of negative number"),
+	OutOfDomain:            errors.New("argument out of the function domain"),
+}
+
+// Valid returns true if e is one of the error codes defined above.
+func (e Error) Valid() bool {
+	return int(e) < len(code2err)
 }
 
 func (e Error) Value() error {
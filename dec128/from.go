@@ -10,11 +10,26 @@ import (
 	"github.com/profe-ajedrez/badassitron/dec128/uint128"
 )
 
+// ErrInvalidDecimal is returned by NewFromString and NewFromBytes when the input is not a valid decimal.
+var ErrInvalidDecimal = deferr.New("invalid decimal number")
+
 func NewFromString(s string) (Dec128, error) {
 	v := FromString(s)
 
 	if v.IsNaN() {
-		return Dec128{}, deferr.New("invalid decimal number")
+		return Dec128{}, ErrInvalidDecimal
+	}
+
+	return v, nil
+}
+
+// NewFromBytes is like NewFromString, but parses b in place, without converting it to a string.
+// It doesn't allocate, so it suits decoding large batches straight from a read buffer.
+func NewFromBytes(b []byte) (Dec128, error) {
+	v := FromString(b)
+
+	if v.IsNaN() {
+		return Dec128{}, ErrInvalidDecimal
 	}
 
 	return v, nil
@@ -25,10 +40,32 @@ func NewFromInt(i int) Dec128 {
 }
 
 // FromString creates a new Dec128 from a string.
-// The string must be in the format of [+-][0-9]+(.[0-9]+)?
+// The string must be in the format of [+-][0-9]*(.[0-9]*)?([eE][+-]?[0-9]+)?, with at least one digit in the mantissa,
+// and the digits may be grouped by the separators set with SetDigitSeparators, "_" by default.
+// Values in exponent notation are normalized into the MaxPrecision digits window, if the value
+// needs more digits after the decimal point it returns NaN with Underflow, and if the coefficient gets
+// too large it returns NaN with Overflow.
 // In case of empty string, it returns Zero.
 // In case of errors, it returns NaN with the corresponding error.
+//
+// Examples:
+//
+//	FromString("1.5e-3") = 0.0015
+//	FromString("+12") = 12
+//	FromString("1_000.50") = 1000.50
+//	FromString(".5") = 0.5
 func FromString[S string | []byte](s S) Dec128 {
+	d := parsePlain(s)
+	if d.err != errors.None {
+		// only inputs outside the plain format pay for the extended parsing,
+		// which reports the same errors as parsePlain for the plain format
+		return parseExtended(s)
+	}
+	return d
+}
+
+// parsePlain parses the [+-][0-9]+(.[0-9]+)? format, the one every formatting method of Dec128 produces.
+func parsePlain[S string | []byte](s S) Dec128 {
 	sz := len(s)
 
 	switch sz {
@@ -132,6 +169,143 @@ func FromString[S string | []byte](s S) Dec128 {
 	return Dec128{coef: coef, exp: uint8(prec), neg: neg}
 }
 
+// parseExtended parses the formats FromString accepts beyond the plain one: exponent notation and digit separators.
+func parseExtended[S string | []byte](s S) Dec128 {
+	sz := len(s)
+	i := 0
+	neg := false
+
+	if i < sz && (s[i] == '+' || s[i] == '-') {
+		neg = s[i] == '-'
+		i++
+	}
+
+	var coef uint128.Uint128
+	var err errors.Error
+	var frac int
+	var dot, digits bool
+
+	for ; i < sz && s[i] != 'e' && s[i] != 'E'; i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			if coef, err = coef.Mul64(10); err != errors.None {
+				return NaN(err)
+			}
+			if coef, err = coef.Add64(uint64(c - '0')); err != errors.None {
+				return NaN(err)
+			}
+			if dot {
+				frac++
+			}
+			digits = true
+		case c == '.':
+			if dot {
+				return NaN(errors.InvalidFormat)
+			}
+			dot = true
+		case isDigitSeparator(c):
+			// separators only group digits, so they must sit between two of them
+			if i == 0 || i+1 >= sz || !isDigit(s[i-1]) || !isDigit(s[i+1]) {
+				return NaN(errors.InvalidFormat)
+			}
+		default:
+			return NaN(errors.InvalidFormat)
+		}
+	}
+
+	if !digits {
+		return NaN(errors.InvalidFormat)
+	}
+
+	scale := frac
+	sci := i < sz
+	if sci {
+		e, ok := parseExponent(s[i+1:])
+		if !ok {
+			return NaN(errors.InvalidFormat)
+		}
+		scale -= e
+	}
+
+	if !sci && frac > int(MaxPrecision) {
+		return NaN(errors.PrecisionOutOfRange)
+	}
+
+	if coef.IsZero() {
+		return Zero
+	}
+
+	if scale < 0 {
+		if -scale >= len(Pow10Uint128) {
+			return NaN(errors.Overflow)
+		}
+		if coef, err = coef.Mul(Pow10Uint128[-scale]); err != errors.None {
+			return NaN(errors.Overflow)
+		}
+		scale = 0
+	}
+
+	// drop the trailing zeros that don't fit in the precision window
+	for scale > int(MaxPrecision) {
+		q, r, _ := coef.QuoRem64(10)
+		if r != 0 {
+			return NaN(errors.Underflow)
+		}
+		coef = q
+		scale--
+	}
+
+	return Dec128{coef: coef, exp: uint8(scale), neg: neg}
+}
+
+// parseExponent parses the [+-]?[0-9]+ exponent after the 'e' of exponent notation.
+// Exponents too large to produce a Dec128 are clamped, the caller reports them as Overflow or Underflow.
+func parseExponent[S string | []byte](s S) (int, bool) {
+	i := 0
+	neg := false
+
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		neg = s[0] == '-'
+		i++
+	}
+
+	if i == len(s) {
+		return 0, false
+	}
+
+	e := 0
+	for ; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return 0, false
+		}
+		if e < maxExponent {
+			e = e*10 + int(s[i]-'0')
+		}
+	}
+
+	if neg {
+		return -min(e, maxExponent), true
+	}
+	return min(e, maxExponent), true
+}
+
+// maxExponent is larger than the exponent of any Dec128 written in exponent notation with a 128-bit mantissa.
+const maxExponent = 1000
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isDigitSeparator(c byte) bool {
+	for i := range len(digitSeparators) {
+		if digitSeparators[i] == c {
+			return true
+		}
+	}
+	return false
+}
+
 // FromInt creates a new Dec128 from an int.
 func FromInt(i int) Dec128 {
 	if i == 0 {
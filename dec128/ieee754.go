@@ -0,0 +1,306 @@
+package dec128
+
+import (
+	"github.com/profe-ajedrez/badassitron/dec128/errors"
+	"github.com/profe-ajedrez/badassitron/dec128/uint128"
+)
+
+// IEEE 754-2008 decimal128 interchange format.
+//
+// A decimal128 holds a sign, a coefficient of up to 34 digits and an exponent between -6176 and 6111,
+// stored with a bias of 6176. The coefficient is encoded either as a binary integer (BID) or
+// with three digits per 10-bit declet (DPD). Both encodings share the layout of the special values:
+// the 5 bits after the sign are 11110 for infinities and 11111 for NaNs.
+const (
+	ieeeBias    = 6176
+	ieeeMaxExp  = 12287 // largest biased exponent
+	ieeeSign    = uint64(1) << 63
+	ieeeInf     = uint64(0x1e) << 58
+	ieeeNaN     = uint64(0x1f) << 58
+	ieeeSpecial = uint64(0x1f) << 58
+)
+
+// ieeeMaxCoef is the largest decimal128 coefficient, 10^34 - 1.
+var ieeeMaxCoef = uint128.Uint128{Lo: 0x378d8e63ffffffff, Hi: 0x1ed09bead87c0}
+
+// BID returns the decimal as an IEEE 754-2008 decimal128 bit pattern, with the coefficient encoded as a binary integer.
+// NaN is encoded as a quiet NaN carrying the reason in its payload, which FromBID restores.
+// It returns an error if the decimal has more than 34 significant digits.
+func (decimal Dec128) BID() (uint128.Uint128, error) {
+	if decimal.err != errors.None {
+		return uint128.Uint128{Lo: uint64(decimal.err), Hi: ieeeNaN}, nil
+	}
+
+	coef, exp, err := decimal.ieeeParts()
+	if err != errors.None {
+		return uint128.Zero, err.Value()
+	}
+
+	// the coefficient is at most 10^34 - 1 < 2^113, so it never needs the 11 form of the combination field
+	bits := coef
+	bits.Hi |= uint64(exp) << 49
+	if decimal.neg && !decimal.coef.IsZero() {
+		bits.Hi |= ieeeSign
+	}
+
+	return bits, nil
+}
+
+// BIDBytes returns the BID bit pattern in little-endian order, the one of the Intel decimal library and most drivers.
+func (decimal Dec128) BIDBytes() ([16]byte, error) {
+	bits, err := decimal.BID()
+	return bits.Bytes(), err
+}
+
+// FromBID creates a new Dec128 from an IEEE 754-2008 decimal128 bit pattern with a binary integer coefficient.
+// Infinities return NaN with Overflow (positive) or Underflow (negative), and NaNs return NaN with the
+// reason stored in their payload, or NotANumber.
+// Values that need more than MaxPrecision digits after the decimal point return NaN with Underflow, and values
+// whose coefficient does not fit return NaN with Overflow.
+func FromBID(bits uint128.Uint128) Dec128 {
+	if d, ok := fromIEEESpecial(bits, uint128.Uint128{Lo: bits.Lo, Hi: bits.Hi & (1<<46 - 1)}); ok {
+		return d
+	}
+
+	neg := bits.Hi&ieeeSign != 0
+
+	if (bits.Hi>>61)&3 == 3 {
+		// the 11 form implies a coefficient over 2^113, which is non-canonical and reads as zero
+		return fromIEEEParts(uint128.Zero, int((bits.Hi>>47)&0x3fff), neg)
+	}
+
+	coef := uint128.Uint128{Lo: bits.Lo, Hi: bits.Hi & (1<<49 - 1)}
+	if coef.Compare(ieeeMaxCoef) > 0 {
+		coef = uint128.Zero
+	}
+
+	return fromIEEEParts(coef, int((bits.Hi>>49)&0x3fff), neg)
+}
+
+// FromBIDBytes creates a new Dec128 from a BID bit pattern in little-endian order, as BIDBytes returns it.
+func FromBIDBytes(bs [16]byte) Dec128 {
+	return FromBID(uint128.FromBytes(bs))
+}
+
+// DPD returns the decimal as an IEEE 754-2008 decimal128 bit pattern, with the coefficient encoded as densely packed decimal.
+// NaN is encoded as a quiet NaN carrying the reason in its payload, which FromDPD restores.
+// It returns an error if the decimal has more than 34 significant digits.
+func (decimal Dec128) DPD() (uint128.Uint128, error) {
+	if decimal.err != errors.None {
+		return uint128.Uint128{Lo: uint64(dpdEncode[decimal.err]), Hi: ieeeNaN}, nil
+	}
+
+	coef, exp, err := decimal.ieeeParts()
+	if err != errors.None {
+		return uint128.Zero, err.Value()
+	}
+
+	// 11 declets of 3 digits, from the least significant, and the leading digit
+	var bits uint128.Uint128
+	for i := range 11 {
+		var r uint64
+		coef, r, _ = coef.QuoRem64(1000)
+		bits = bits.Or(uint128.FromUint64(uint64(dpdEncode[r])).Lsh(uint(10 * i)))
+	}
+	msd := coef.Lo
+
+	// the combination field holds the 2 leading bits of the exponent and the leading digit
+	var comb uint64
+	if msd < 8 {
+		comb = uint64(exp>>12)<<3 | msd
+	} else {
+		comb = 3<<3 | uint64(exp>>12)<<1 | (msd - 8)
+	}
+
+	bits.Hi |= comb<<58 | uint64(exp&0xfff)<<46
+	if decimal.neg && !decimal.coef.IsZero() {
+		bits.Hi |= ieeeSign
+	}
+
+	return bits, nil
+}
+
+// DPDBytes returns the DPD bit pattern in little-endian order.
+func (decimal Dec128) DPDBytes() ([16]byte, error) {
+	bits, err := decimal.DPD()
+	return bits.Bytes(), err
+}
+
+// FromDPD creates a new Dec128 from an IEEE 754-2008 decimal128 bit pattern with a densely packed decimal coefficient.
+// It handles special and out of range values as FromBID does.
+func FromDPD(bits uint128.Uint128) Dec128 {
+	if d, ok := fromIEEESpecial(bits, dpdDecode(bits, 11)); ok {
+		return d
+	}
+
+	comb := (bits.Hi >> 58) & 0x1f
+	var expHi, msd uint64
+	if comb>>3 == 3 {
+		expHi, msd = (comb>>1)&3, 8+comb&1
+	} else {
+		expHi, msd = comb>>3, comb&7
+	}
+
+	// at most 10^34 - 1, it can't overflow
+	lead, _ := uint128.Pow10Uint128[33].Mul64(msd)
+	coef, _ := dpdDecode(bits, 11).Add(lead)
+
+	return fromIEEEParts(coef, int(expHi<<12|(bits.Hi>>46)&0xfff), bits.Hi&ieeeSign != 0)
+}
+
+// FromDPDBytes creates a new Dec128 from a DPD bit pattern in little-endian order, as DPDBytes returns it.
+func FromDPDBytes(bs [16]byte) Dec128 {
+	return FromDPD(uint128.FromBytes(bs))
+}
+
+// ieeeParts returns the coefficient and biased exponent of the decimal in a decimal128,
+// dropping trailing zeros when the coefficient has more than 34 digits.
+func (decimal Dec128) ieeeParts() (uint128.Uint128, int, errors.Error) {
+	coef := decimal.coef
+	exp := -int(decimal.exp)
+
+	for coef.Compare(ieeeMaxCoef) > 0 {
+		q, r, _ := coef.QuoRem64(10)
+		if r != 0 {
+			return uint128.Zero, 0, errors.PrecisionOutOfRange
+		}
+		coef = q
+		exp++
+	}
+
+	return coef, exp + ieeeBias, errors.None
+}
+
+// fromIEEESpecial returns the NaN an infinity or NaN bit pattern maps to, given the payload of NaNs.
+func fromIEEESpecial(bits uint128.Uint128, payload uint128.Uint128) (Dec128, bool) {
+	switch bits.Hi & ieeeSpecial {
+	case ieeeInf:
+		if bits.Hi&ieeeSign != 0 {
+			return NaN(errors.Underflow), true
+		}
+		return NaN(errors.Overflow), true
+	case ieeeNaN:
+		if payload.Hi == 0 && payload.Lo < 256 && errors.Error(payload.Lo).Valid() {
+			return NaN(errors.Error(payload.Lo)), true
+		}
+		return NaN(errors.NotANumber), true
+	}
+
+	return Dec128{}, false
+}
+
+// fromIEEEParts creates the decimal coef * 10^(exp - bias).
+func fromIEEEParts(coef uint128.Uint128, exp int, neg bool) Dec128 {
+	if exp > ieeeMaxExp {
+		// only reachable from non-canonical patterns
+		coef = uint128.Zero
+	}
+
+	e := exp - ieeeBias
+
+	if coef.IsZero() {
+		return Dec128{exp: uint8(min(max(-e, 0), int(MaxPrecision)))}
+	}
+
+	if e > 0 {
+		if e >= len(Pow10Uint128) {
+			return NaN(errors.Overflow)
+		}
+		c, err := coef.Mul(Pow10Uint128[e])
+		if err != errors.None {
+			return NaN(errors.Overflow)
+		}
+		return Dec128{coef: c, neg: neg}
+	}
+
+	// drop the trailing zeros that don't fit in the precision window
+	for -e > int(MaxPrecision) {
+		q, r, _ := coef.QuoRem64(10)
+		if r != 0 {
+			return NaN(errors.Underflow)
+		}
+		coef = q
+		e++
+	}
+
+	return Dec128{coef: coef, exp: uint8(-e), neg: neg}
+}
+
+// dpdDecode returns the value of the n least significant declets of bits.
+func dpdDecode(bits uint128.Uint128, n int) uint128.Uint128 {
+	var coef uint128.Uint128
+	for i := n - 1; i >= 0; i-- {
+		declet := bits.Rsh(uint(10*i)).Lo & 0x3ff
+		coef, _ = coef.Mul64(1000)
+		coef, _ = coef.Add64(uint64(dpdDecodeDeclet(uint16(declet))))
+	}
+	return coef
+}
+
+// dpdDecodeDeclet returns the 3 digits number encoded in the 10-bit declet pqr stu v wxy, including the non-canonical ones.
+func dpdDecodeDeclet(declet uint16) uint16 {
+	b := func(i uint) uint16 { return (declet >> i) & 1 }
+	p, q, r := b(9), b(8), b(7)
+	s, t, u := b(6), b(5), b(4)
+	v, w, x, y := b(3), b(2), b(1), b(0)
+
+	pqr := p<<2 | q<<1 | r
+	stu := s<<2 | t<<1 | u
+	wxy := w<<2 | x<<1 | y
+
+	var d1, d2, d3 uint16
+	switch {
+	case v == 0:
+		d1, d2, d3 = pqr, stu, wxy
+	case w == 0 && x == 0:
+		d1, d2, d3 = pqr, stu, 8+y
+	case w == 0 && x == 1:
+		d1, d2, d3 = pqr, 8+u, s<<2|t<<1|y
+	case w == 1 && x == 0:
+		d1, d2, d3 = 8+r, stu, p<<2|q<<1|y
+	case s == 0 && t == 0:
+		d1, d2, d3 = 8+r, 8+u, p<<2|q<<1|y
+	case s == 0 && t == 1:
+		d1, d2, d3 = 8+r, p<<2|q<<1|u, 8+y
+	case s == 1 && t == 0:
+		d1, d2, d3 = pqr, 8+u, 8+y
+	default:
+		d1, d2, d3 = 8+r, 8+u, 8+y
+	}
+
+	return d1*100 + d2*10 + d3
+}
+
+// dpdEncode maps the numbers 0 to 999 to their canonical declet.
+var dpdEncode = func() (t [1000]uint16) {
+	for n := range t {
+		d1, d2, d3 := uint16(n/100), uint16(n/10%10), uint16(n%10)
+		a, e, i := d1>>3, d2>>3, d3>>3
+		bcd, fgh, jkm := d1&7, d2&7, d3&7
+		f, g := (d2>>2)&1, (d2>>1)&1
+		j, k := (d3>>2)&1, (d3>>1)&1
+		d, h, m := d1&1, d2&1, d3&1
+
+		var declet uint16
+		switch a<<2 | e<<1 | i {
+		case 0b000:
+			declet = bcd<<7 | fgh<<4 | jkm
+		case 0b001:
+			declet = bcd<<7 | fgh<<4 | 0b1000 | m
+		case 0b010:
+			declet = bcd<<7 | (j<<2|k<<1|h)<<4 | 0b1010 | m
+		case 0b100:
+			declet = (j<<2|k<<1|d)<<7 | fgh<<4 | 0b1100 | m
+		case 0b110:
+			declet = (j<<2|k<<1|d)<<7 | h<<4 | 0b1110 | m
+		case 0b101:
+			declet = (f<<2|g<<1|d)<<7 | (0b010|h)<<4 | 0b1110 | m
+		case 0b011:
+			declet = bcd<<7 | (0b100|h)<<4 | 0b1110 | m
+		default:
+			declet = d<<7 | (0b110|h)<<4 | 0b1110 | m
+		}
+		t[n] = declet
+	}
+	return t
+}()
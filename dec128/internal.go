@@ -1,6 +1,8 @@
 package dec128
 
 import (
+	"math/bits"
+
 	"github.com/profe-ajedrez/badassitron/dec128/errors"
 	"github.com/profe-ajedrez/badassitron/dec128/uint128"
 )
@@ -34,6 +36,30 @@ var (
 	zeros = [...]byte{'0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0'}
 )
 
+// tryAdd64 is the fast path of Add for operands with the same exponent and coefficients that fit in 64 bits.
+// It returns the same results as tryAdd, and false when the operands don't qualify.
+func (decimal Dec128) tryAdd64(other Dec128) (Dec128, bool) {
+	if decimal.exp != other.exp || decimal.coef.Hi != 0 || other.coef.Hi != 0 {
+		return Dec128{}, false
+	}
+
+	a, b := decimal.coef.Lo, other.coef.Lo
+
+	if decimal.neg == other.neg {
+		lo, carry := bits.Add64(a, b, 0)
+		return Dec128{coef: uint128.Uint128{Lo: lo, Hi: carry}, exp: decimal.exp, neg: decimal.neg}, true
+	}
+
+	switch {
+	case a > b:
+		return Dec128{coef: uint128.FromUint64(a - b), exp: decimal.exp, neg: decimal.neg}, true
+	case a == b:
+		return Zero, true
+	default:
+		return Dec128{coef: uint128.FromUint64(b - a), exp: decimal.exp, neg: other.neg}, true
+	}
+}
+
 func (decimal Dec128) tryAdd(other Dec128) (Dec128, bool) {
 	prec := max(decimal.exp, other.exp)
 
@@ -112,6 +138,18 @@ func (decimal Dec128) trySub(other Dec128) (Dec128, bool) {
 	}
 }
 
+// tryMul64 is the fast path of Mul for coefficients that fit in 64 bits, whose product always fits in 128 bits.
+// It returns the same results as tryMul, and false when the operands don't qualify.
+func (decimal Dec128) tryMul64(other Dec128) (Dec128, bool) {
+	prec := decimal.exp + other.exp
+	if decimal.coef.Hi != 0 || other.coef.Hi != 0 || prec > MaxPrecision {
+		return Dec128{}, false
+	}
+
+	hi, lo := bits.Mul64(decimal.coef.Lo, other.coef.Lo)
+	return Dec128{coef: uint128.Uint128{Lo: lo, Hi: hi}, exp: prec, neg: decimal.neg != other.neg}, true
+}
+
 func (decimal Dec128) tryMul(other Dec128) (Dec128, bool) {
 	neg := decimal.neg != other.neg
 	prec := decimal.exp + other.exp
@@ -145,6 +183,35 @@ func (decimal Dec128) tryMul(other Dec128) (Dec128, bool) {
 	}
 }
 
+// tryDiv64 is the fast path of Div for coefficients that fit in 64 bits, when the dividend needs to be
+// scaled by at most 10^19, so the scaled dividend fits in 128 bits.
+// It returns the same results as tryDiv, and false when the operands don't qualify.
+func (decimal Dec128) tryDiv64(other Dec128) (Dec128, bool) {
+	if decimal.coef.Hi != 0 || other.coef.Hi != 0 {
+		return Dec128{}, false
+	}
+
+	factor := other.exp
+	prec := decimal.exp
+	if prec < defaultPrecision {
+		factor = factor + defaultPrecision - prec
+		prec = defaultPrecision
+	}
+
+	if factor > MaxPrecision {
+		return Dec128{}, false
+	}
+
+	d := other.coef.Lo
+	hi, lo := bits.Mul64(decimal.coef.Lo, Pow10Uint64[factor])
+
+	// long division by a 64-bit divisor, one 64-bit word at a time
+	qhi := hi / d
+	qlo, _ := bits.Div64(hi%d, lo, d)
+
+	return Dec128{coef: uint128.Uint128{Lo: qlo, Hi: qhi}, exp: prec, neg: decimal.neg != other.neg}, true
+}
+
 func (decimal Dec128) tryDiv(other Dec128) (Dec128, bool) {
 	neg := decimal.neg != other.neg
 	factor := other.exp
@@ -161,6 +228,47 @@ func (decimal Dec128) tryDiv(other Dec128) (Dec128, bool) {
 	return Dec128{coef: q, exp: prec, neg: neg}, true
 }
 
+// tryDivRound divides with both coefficients scaled so the quotient has prec digits after the decimal point,
+// and rounds it with mode. It fails if the scaled operands or the quotient don't fit.
+func (decimal Dec128) tryDivRound(other Dec128, prec uint8, mode RoundingMode) (Dec128, bool, bool) {
+	var u, c, d uint128.Uint128
+	var err errors.Error
+
+	if k := int(prec) + int(other.exp) - int(decimal.exp); k >= 0 {
+		u, c = decimal.coef.MulCarry(Pow10Uint128[k])
+		d = other.coef
+	} else {
+		u = decimal.coef
+		d, err = other.coef.Mul(Pow10Uint128[-k])
+		if err != errors.None {
+			return Dec128{}, false, false
+		}
+	}
+
+	q, r, err := uint128.QuoRem256By128(u, c, d)
+	if err != errors.None {
+		return Dec128{}, false, false
+	}
+
+	neg := decimal.neg != other.neg
+	inexact := !r.IsZero()
+
+	// r < d, so d - r compares with r as d / 2 does without overflowing
+	h, _ := d.Sub(r)
+	if inexact && roundsAwayFromZero(Dec128{coef: q}, r.Compare(h), neg, mode) {
+		q, err = q.Add64(1)
+		if err != errors.None {
+			return Dec128{}, false, false
+		}
+	}
+
+	if q.IsZero() {
+		return Zero, inexact, true
+	}
+
+	return Dec128{coef: q, exp: prec, neg: neg}, inexact, true
+}
+
 func (decimal Dec128) tryQuoRem(other Dec128) (Dec128, Dec128, bool) {
 	var factor uint8
 	var u uint128.Uint128
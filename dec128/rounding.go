@@ -2,10 +2,118 @@ package dec128
 
 import "github.com/profe-ajedrez/badassitron/dec128/errors"
 
+// RoundingMode identifies one of the rounding methods of Dec128, so the method can be chosen at runtime.
+type RoundingMode uint8
+
+const (
+	// RoundingHalfAwayFromZero selects RoundHalfAwayFromZero. It is the zero value, so it's the default mode.
+	RoundingHalfAwayFromZero RoundingMode = iota
+	// RoundingHalfTowardZero selects RoundHalfTowardZero.
+	RoundingHalfTowardZero
+	// RoundingHalfEven selects RoundBank.
+	RoundingHalfEven
+	// RoundingDown selects RoundDown.
+	RoundingDown
+	// RoundingUp selects RoundUp.
+	RoundingUp
+	// RoundingTowardZero selects RoundTowardZero.
+	RoundingTowardZero
+	// RoundingAwayFromZero selects RoundAwayFromZero.
+	RoundingAwayFromZero
+)
+
 func (decimal Dec128) Round(prec uint8) Dec128 {
 	return decimal.RoundHalfAwayFromZero(prec)
 }
 
+// RoundWithMode rounds the decimal to the specified precision using the method selected by mode.
+// An unknown mode returns NaN.
+func (decimal Dec128) RoundWithMode(prec uint8, mode RoundingMode) Dec128 {
+	switch mode {
+	case RoundingHalfAwayFromZero:
+		return decimal.RoundHalfAwayFromZero(prec)
+	case RoundingHalfTowardZero:
+		return decimal.RoundHalfTowardZero(prec)
+	case RoundingHalfEven:
+		return decimal.RoundBank(prec)
+	case RoundingDown:
+		return decimal.RoundDown(prec)
+	case RoundingUp:
+		return decimal.RoundUp(prec)
+	case RoundingTowardZero:
+		return decimal.RoundTowardZero(prec)
+	case RoundingAwayFromZero:
+		return decimal.RoundAwayFromZero(prec)
+	}
+
+	return NaN(errors.InvalidFormat)
+}
+
+// RoundToIncrement rounds the decimal to a multiple of increment using the method selected by mode.
+// The sign of increment is ignored. A zero increment returns NaN.
+//
+// Examples:
+//
+//	RoundToIncrement(1.024, 0.05, RoundingHalfAwayFromZero) = 1.00
+//	RoundToIncrement(1.025, 0.05, RoundingHalfAwayFromZero) = 1.05
+//	RoundToIncrement(1235, 10, RoundingHalfTowardZero) = 1230
+//	RoundToIncrement(1236, 10, RoundingHalfTowardZero) = 1240
+//	RoundToIncrement(-1236, 10, RoundingDown) = -1240
+func (decimal Dec128) RoundToIncrement(increment Dec128, mode RoundingMode) Dec128 {
+	if decimal.err != errors.None {
+		return decimal
+	}
+
+	if increment.err != errors.None {
+		return increment
+	}
+
+	if increment.IsZero() {
+		return NaN(errors.DivisionByZero)
+	}
+
+	if mode > RoundingAwayFromZero {
+		return NaN(errors.InvalidFormat)
+	}
+
+	inc := increment.Abs()
+	q, r := decimal.QuoRem(inc)
+	if q.IsNaN() {
+		return q
+	}
+
+	if !r.IsZero() && roundsAwayFromZero(q, r.Abs().MulInt(2).Compare(inc), decimal.neg, mode) {
+		if decimal.neg {
+			q = q.SubInt(1)
+		} else {
+			q = q.AddInt(1)
+		}
+	}
+
+	return q.Mul(inc)
+}
+
+// roundsAwayFromZero tells whether a truncated quotient q must move one step away from zero under mode,
+// given how twice the discarded remainder compares with the divisor (half).
+func roundsAwayFromZero(q Dec128, half int, neg bool, mode RoundingMode) bool {
+	switch mode {
+	case RoundingHalfAwayFromZero:
+		return half >= 0
+	case RoundingHalfTowardZero:
+		return half > 0
+	case RoundingHalfEven:
+		return half > 0 || (half == 0 && q.coef.Lo%2 == 1)
+	case RoundingDown:
+		return neg
+	case RoundingUp:
+		return !neg
+	case RoundingAwayFromZero:
+		return true
+	}
+
+	return false
+}
+
 // RoundDown (or Floor) rounds the decimal to the specified precision using Round Down method (https://en.wikipedia.org/wiki/Rounding#Rounding_down).
 //
 // Examples:
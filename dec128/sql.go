@@ -0,0 +1,112 @@
+package dec128
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+// NullDec128 represents a Dec128 that may be null, as in nullable SQL columns or JSON null.
+type NullDec128 struct {
+	Dec128 Dec128
+	Valid  bool // Valid is true if Dec128 is not NULL
+}
+
+// NewNullDec128 returns a valid NullDec128 holding d.
+func NewNullDec128(d Dec128) NullDec128 {
+	return NullDec128{Dec128: d, Valid: true}
+}
+
+// Scan implements the sql.Scanner interface.
+// NULL sets Valid to false, any other value is scanned as Dec128.Scan does.
+func (null *NullDec128) Scan(src any) error {
+	if src == nil {
+		*null = NullDec128{}
+		return nil
+	}
+
+	d, err := scan(src)
+	if err != nil {
+		return err
+	}
+
+	*null = NullDec128{Dec128: d, Valid: true}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (null NullDec128) Value() (driver.Value, error) {
+	if !null.Valid {
+		return nil, nil
+	}
+	return null.Dec128.Value()
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (null NullDec128) MarshalJSON() ([]byte, error) {
+	if !null.Valid {
+		return nullValue, nil
+	}
+	return null.Dec128.MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (null *NullDec128) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullValue) {
+		*null = NullDec128{}
+		return nil
+	}
+
+	var d Dec128
+	if err := d.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	*null = NullDec128{Dec128: d, Valid: true}
+	return nil
+}
+
+// String returns "NULL" if the value is not valid, otherwise the string representation of the Dec128.
+func (null NullDec128) String() string {
+	if !null.Valid {
+		return "NULL"
+	}
+	return null.Dec128.String()
+}
+
+func scan(src any) (Dec128, error) {
+	var d Dec128
+
+	switch v := src.(type) {
+	case []byte:
+		d = FromString(v)
+	case string:
+		d = FromString(v)
+	case int:
+		d = FromInt(v)
+	case int32:
+		d = FromInt64(int64(v))
+	case int64:
+		d = FromInt64(v)
+	case uint64:
+		d = DecodeFromUint64(v, 0)
+	case float32:
+		d = FromString(strconv.FormatFloat(float64(v), 'f', -1, 32))
+	case float64:
+		d = FromFloat64(v)
+	case nil:
+		return Zero, fmt.Errorf("can't scan NULL to Dec128, use NullDec128 for nullable columns")
+	default:
+		return Zero, fmt.Errorf("can't scan %T to Dec128: %T is not supported", src, src)
+	}
+
+	if d.IsNaN() {
+		if b, ok := src.([]byte); ok {
+			src = string(b)
+		}
+		return Zero, fmt.Errorf("can't scan %v to Dec128: %w", src, d.ErrorDetails())
+	}
+
+	return d, nil
+}
@@ -0,0 +1,296 @@
+package dec128
+
+import (
+	"math/big"
+	"slices"
+
+	"github.com/profe-ajedrez/badassitron/dec128/errors"
+)
+
+// StatsOption sets how the statistical helpers round their results.
+type StatsOption func(*statsOptions)
+
+type statsOptions struct {
+	prec  uint8
+	mode  RoundingMode
+	exact bool
+}
+
+// WithPrecision makes a statistical helper round its result to prec digits after the decimal point using mode.
+//
+// Without it, sums are returned exact, and the other results are rounded half away from zero at the default precision.
+func WithPrecision(prec uint8, mode RoundingMode) StatsOption {
+	return func(o *statsOptions) {
+		o.prec = prec
+		o.mode = mode
+		o.exact = false
+	}
+}
+
+func newStatsOptions(exact bool, opts []StatsOption) statsOptions {
+	o := statsOptions{prec: defaultPrecision, mode: RoundingHalfAwayFromZero, exact: exact}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// SumOf returns the sum of values. Empty values sum to zero.
+// The sum is computed exactly, so it only overflows if the result itself does not fit in a Dec128,
+// no matter the order of the values. The first NaN in values is returned as it is.
+func SumOf(values []Dec128, opts ...StatsOption) Dec128 {
+	if d, ok := firstNaN(values); ok {
+		return d
+	}
+
+	o := newStatsOptions(true, opts)
+	sum, exp := sumRat(values)
+
+	return o.result(sum, exp)
+}
+
+// CumSum returns the running totals of values: the i-th result is the sum of values[0] to values[i].
+// Once a value is NaN, it is the result for it and for all the following values.
+func CumSum(values []Dec128, opts ...StatsOption) []Dec128 {
+	o := newStatsOptions(true, opts)
+	sums := make([]Dec128, len(values))
+	sum := new(big.Rat)
+	var exp uint8
+
+	for i, d := range values {
+		if d.err != errors.None {
+			for j := i; j < len(values); j++ {
+				sums[j] = d
+			}
+			break
+		}
+
+		sum.Add(sum, d.rat())
+		exp = max(exp, d.exp)
+		sums[i] = o.result(sum, exp)
+	}
+
+	return sums
+}
+
+// Mean returns the arithmetic mean of values.
+// It returns NaN with DivisionByZero if values is empty.
+func Mean(values []Dec128, opts ...StatsOption) Dec128 {
+	if len(values) == 0 {
+		return NaN(errors.DivisionByZero)
+	}
+
+	if d, ok := firstNaN(values); ok {
+		return d
+	}
+
+	o := newStatsOptions(false, opts)
+	sum, _ := sumRat(values)
+
+	return o.result(sum.Quo(sum, new(big.Rat).SetInt64(int64(len(values)))), 0)
+}
+
+// WeightedAvg returns the average of values weighted by weights, that is, sum(values[i] * weights[i]) / sum(weights).
+// It returns NaN with InvalidFormat if values and weights have different lengths, with Negative if a weight is negative,
+// and with DivisionByZero if the weights sum to zero.
+func WeightedAvg(values []Dec128, weights []Dec128, opts ...StatsOption) Dec128 {
+	if len(values) != len(weights) {
+		return NaN(errors.InvalidFormat)
+	}
+
+	if d, ok := firstNaN(values); ok {
+		return d
+	}
+
+	if d, ok := firstNaN(weights); ok {
+		return d
+	}
+
+	num := new(big.Rat)
+	den := new(big.Rat)
+	for i, w := range weights {
+		if w.IsNegative() {
+			return NaN(errors.Negative)
+		}
+		wr := w.rat()
+		den.Add(den, wr)
+		num.Add(num, wr.Mul(wr, values[i].rat()))
+	}
+
+	if den.Sign() == 0 {
+		return NaN(errors.DivisionByZero)
+	}
+
+	o := newStatsOptions(false, opts)
+	return o.result(num.Quo(num, den), 0)
+}
+
+// Median returns the median of values, the mean of the two middle values when their number is even.
+// It returns NaN with DivisionByZero if values is empty.
+func Median(values []Dec128, opts ...StatsOption) Dec128 {
+	return Percentile(values, FromInt(50), opts...)
+}
+
+// Percentile returns the p-th percentile of values, for p between 0 and 100, interpolating linearly between
+// the closest ranks like spreadsheets' PERCENTILE.INC does.
+// It returns NaN with OutOfDomain if p is out of range, and with DivisionByZero if values is empty.
+//
+// Examples:
+//
+//	Percentile([1, 2, 3, 4], 50) = 2.5
+//	Percentile([1, 2, 3, 4], 90) = 3.7
+func Percentile(values []Dec128, p Dec128, opts ...StatsOption) Dec128 {
+	if p.err != errors.None {
+		return p
+	}
+
+	if p.IsNegative() || p.GreaterThan(Decimal100) {
+		return NaN(errors.OutOfDomain)
+	}
+
+	if len(values) == 0 {
+		return NaN(errors.DivisionByZero)
+	}
+
+	if d, ok := firstNaN(values); ok {
+		return d
+	}
+
+	sorted := slices.Clone(values)
+	slices.SortFunc(sorted, Dec128.Compare)
+
+	// rank h = (n - 1) * p / 100, the result lies between the values at floor(h) and floor(h) + 1
+	h := p.rat()
+	h.Mul(h, new(big.Rat).SetFrac64(int64(len(sorted)-1), 100))
+	i := new(big.Int).Quo(h.Num(), h.Denom()).Int64()
+
+	r := sorted[i].rat()
+	if int(i) < len(sorted)-1 {
+		frac := h.Sub(h, new(big.Rat).SetInt64(i))
+		step := sorted[i+1].rat()
+		step.Sub(step, r)
+		r.Add(r, step.Mul(step, frac))
+	}
+
+	o := newStatsOptions(false, opts)
+	return o.result(r, 0)
+}
+
+// Variance returns the population variance of values, the mean of the squared deviations from their mean.
+// It returns NaN with DivisionByZero if values is empty.
+func Variance(values []Dec128, opts ...StatsOption) Dec128 {
+	v, d := varianceRat(values, 0)
+	if v == nil {
+		return d
+	}
+	return newStatsOptions(false, opts).result(v, 0)
+}
+
+// SampleVariance returns the sample variance of values, with Bessel's correction (n - 1 denominator).
+// It returns NaN with DivisionByZero if values has less than two elements.
+func SampleVariance(values []Dec128, opts ...StatsOption) Dec128 {
+	v, d := varianceRat(values, 1)
+	if v == nil {
+		return d
+	}
+	return newStatsOptions(false, opts).result(v, 0)
+}
+
+// StdDev returns the population standard deviation of values, the square root of Variance.
+// It returns NaN with DivisionByZero if values is empty.
+func StdDev(values []Dec128, opts ...StatsOption) Dec128 {
+	v, d := varianceRat(values, 0)
+	if v == nil {
+		return d
+	}
+	return newStatsOptions(false, opts).sqrtResult(v)
+}
+
+// SampleStdDev returns the sample standard deviation of values, the square root of SampleVariance.
+// It returns NaN with DivisionByZero if values has less than two elements.
+func SampleStdDev(values []Dec128, opts ...StatsOption) Dec128 {
+	v, d := varianceRat(values, 1)
+	if v == nil {
+		return d
+	}
+	return newStatsOptions(false, opts).sqrtResult(v)
+}
+
+// varianceRat returns the exact variance of values with n - ddof as denominator, or nil and the NaN to return.
+func varianceRat(values []Dec128, ddof int) (*big.Rat, Dec128) {
+	if len(values) <= ddof {
+		return nil, NaN(errors.DivisionByZero)
+	}
+
+	if d, ok := firstNaN(values); ok {
+		return nil, d
+	}
+
+	mean, _ := sumRat(values)
+	mean.Quo(mean, new(big.Rat).SetInt64(int64(len(values))))
+
+	v := new(big.Rat)
+	dev := new(big.Rat)
+	for _, d := range values {
+		dev.Sub(d.rat(), mean)
+		v.Add(v, dev.Mul(dev, dev))
+	}
+
+	return v.Quo(v, new(big.Rat).SetInt64(int64(len(values)-ddof))), Zero
+}
+
+func firstNaN(values []Dec128) (Dec128, bool) {
+	for _, d := range values {
+		if d.err != errors.None {
+			return d, true
+		}
+	}
+	return Zero, false
+}
+
+// sumRat returns the exact sum of values, which must not be NaN, and the largest exponent among them.
+func sumRat(values []Dec128) (*big.Rat, uint8) {
+	sum := new(big.Rat)
+	var exp uint8
+	for _, d := range values {
+		sum.Add(sum, d.rat())
+		exp = max(exp, d.exp)
+	}
+	return sum, exp
+}
+
+// result converts the exact value r into a Dec128, as it is with exp digits after the decimal point
+// when exact results were asked for, rounded as set otherwise.
+func (o statsOptions) result(r *big.Rat, exp uint8) Dec128 {
+	if o.exact {
+		return ratToDec(r, exp, RoundingTowardZero)
+	}
+	return ratToDec(r, o.prec, o.mode)
+}
+
+// sqrtResult returns the square root of the non-negative r, rounded as set.
+func (o statsOptions) sqrtResult(r *big.Rat) Dec128 {
+	if o.prec > MaxPrecision {
+		return NaN(errors.PrecisionOutOfRange)
+	}
+
+	if o.mode > RoundingAwayFromZero {
+		return NaN(errors.InvalidFormat)
+	}
+
+	// s = floor(sqrt(r * 10^(2 * prec))), exact because floor(sqrt(floor(x))) = floor(sqrt(x))
+	scaled := new(big.Int).Mul(r.Num(), new(big.Int).Exp(big.NewInt(10), big.NewInt(2*int64(o.prec)), nil))
+	x := new(big.Int).Quo(scaled, r.Denom())
+	s := new(big.Int).Sqrt(x)
+
+	// compare r * 10^(2 * prec) with s^2 to know if it's exact, and with (s + 1/2)^2 to know the half
+	s2 := new(big.Int).Mul(s, s)
+	inexact := s2.Mul(s2, r.Denom()).Cmp(scaled) != 0
+
+	h := new(big.Int).Lsh(s, 1)
+	h.Add(h, big.NewInt(1))
+	h.Mul(h, h).Mul(h, r.Denom())
+	half := new(big.Int).Lsh(scaled, 2).Cmp(h)
+
+	return roundedFromBig(s, inexact, half, false, o.prec, o.mode)
+}
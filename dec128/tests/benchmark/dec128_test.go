@@ -86,3 +86,148 @@ func BenchmarkDec128JsonMarshal(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkDec128Add64(b *testing.B) {
+	x := dec128.FromString("12345.67")
+	y := dec128.FromString("890.12")
+	z := dec128.FromString("-1234567890.12")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = x.Add(y)
+		_ = x.Add(z)
+	}
+}
+
+func BenchmarkDec128Add128(b *testing.B) {
+	x := dec128.FromString("123456789012345678901234567890.12")
+	y := dec128.FromString("890.12")
+	z := dec128.FromString("-1234567890.123")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = x.Add(y)
+		_ = x.Add(z)
+	}
+}
+
+func BenchmarkDec128Sub64(b *testing.B) {
+	x := dec128.FromString("12345.67")
+	y := dec128.FromString("890.12")
+	z := dec128.FromString("-1234567890.12")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = x.Sub(y)
+		_ = x.Sub(z)
+	}
+}
+
+func BenchmarkDec128Mul64(b *testing.B) {
+	x := dec128.FromString("12345.67")
+	y := dec128.FromString("0.19")
+	z := dec128.FromString("-1234567890.12")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = x.Mul(y)
+		_ = x.Mul(z)
+	}
+}
+
+func BenchmarkDec128Mul128(b *testing.B) {
+	x := dec128.FromString("123456789012345678901234567890.12")
+	y := dec128.FromString("0.19")
+	z := dec128.FromString("-12.12")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = x.Mul(y)
+		_ = x.Mul(z)
+	}
+}
+
+func BenchmarkDec128Div64(b *testing.B) {
+	x := dec128.FromString("12345.67")
+	y := dec128.FromString("1.19")
+	z := dec128.FromString("-3")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = x.Div(y)
+		_ = x.Div(z)
+	}
+}
+
+func BenchmarkDec128Div128(b *testing.B) {
+	x := dec128.FromString("123456789012345678901234.67")
+	y := dec128.FromString("1.19")
+	z := dec128.FromString("-3")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = x.Div(y)
+		_ = x.Div(z)
+	}
+}
+
+func BenchmarkDec128AppendText(b *testing.B) {
+	s1 := dec128.FromString("12345.12")
+	s2 := dec128.FromString("1234567890.12345")
+	s3 := dec128.FromString("123456789012345678901234567890.123456789")
+	buf := make([]byte, 0, dec128.MaxStrLen)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf, _ = s1.AppendText(buf[:0])
+		buf, _ = s2.AppendText(buf[:0])
+		buf, _ = s3.AppendText(buf[:0])
+	}
+}
+
+func BenchmarkDec128AppendJSON(b *testing.B) {
+	s1 := dec128.FromString("12345.12")
+	s2 := dec128.FromString("1234567890.12345")
+	s3 := dec128.FromString("123456789012345678901234567890.123456789")
+	buf := make([]byte, 0, dec128.MaxStrLen+2)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf, _ = s1.AppendJSON(buf[:0])
+		buf, _ = s2.AppendJSON(buf[:0])
+		buf, _ = s3.AppendJSON(buf[:0])
+	}
+}
+
+func BenchmarkDec128MarshalJSON(b *testing.B) {
+	s1 := dec128.FromString("12345.12")
+	s2 := dec128.FromString("1234567890.12345")
+	s3 := dec128.FromString("123456789012345678901234567890.123456789")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = s1.MarshalJSON()
+		_, _ = s2.MarshalJSON()
+		_, _ = s3.MarshalJSON()
+	}
+}
+
+func BenchmarkDec128FromBytes(b *testing.B) {
+	s1 := []byte("12345.12")
+	s2 := []byte("1234567890.12345")
+	s3 := []byte("123456789012345678901234567890.123456789")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = dec128.NewFromBytes(s1)
+		_, _ = dec128.NewFromBytes(s2)
+		_, _ = dec128.NewFromBytes(s3)
+	}
+}
+
+func BenchmarkDec128UnmarshalJSON(b *testing.B) {
+	s1 := []byte(`"12345.12"`)
+	s2 := []byte(`"1234567890.12345"`)
+	s3 := []byte(`"123456789012345678901234567890.123456789"`)
+	var d dec128.Dec128
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = d.UnmarshalJSON(s1)
+		_ = d.UnmarshalJSON(s2)
+		_ = d.UnmarshalJSON(s3)
+	}
+}
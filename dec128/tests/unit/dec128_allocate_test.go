@@ -0,0 +1,130 @@
+package unit
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+)
+
+func joinDecimals(parts []dec128.Dec128) string {
+	s := make([]string, len(parts))
+	for i, p := range parts {
+		s[i] = p.String()
+	}
+	return strings.Join(s, " ")
+}
+
+func TestDecimalSplit(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		a    string
+		n    int
+		prec uint8
+		r    string
+		e    string
+	}
+
+	testCases := [...]testCase{
+		{"100", 3, 2, "33.34 33.33 33.33", ""},
+		{"100", 4, 0, "25 25 25 25", ""},
+		{"-0.05", 3, 2, "-0.02 -0.02 -0.01", ""},
+		{"0.02", 3, 2, "0.01 0.01 0", ""},
+		{"-0.02", 3, 2, "-0.01 -0.01 0", ""},
+		{"0", 2, 2, "0 0", ""},
+		{"10", 1, 0, "10", ""},
+		{"1000.005", 3, 2, "", "rescale to less precision"},
+		{"1", 0, 2, "", "division by zero"},
+		{"1", -1, 2, "", "negative value in unsigned operation"},
+		{"NaN", 2, 2, "", "invalid format"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("TestDecimalSplit(%s, %d, %d)", tc.a, tc.n, tc.prec), func(t *testing.T) {
+			parts, err := dec128.FromString(tc.a).Split(tc.n, tc.prec)
+			if tc.e != "" {
+				if err == nil || err.Error() != tc.e {
+					t.Errorf("expected error %q, got %v", tc.e, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if s := joinDecimals(parts); s != tc.r {
+				t.Errorf("expected %s, got %s", tc.r, s)
+			}
+			if sum := dec128.Sum(dec128.Zero, parts...); !sum.Equal(dec128.FromString(tc.a)) {
+				t.Errorf("expected parts to sum %s, got %s", tc.a, sum)
+			}
+			for i, p := range parts {
+				if p.IsZero() && (p.IsNegative() || !p.Equal(dec128.Zero)) {
+					t.Errorf("expected part %d to be zero, got a negative zero", i)
+				}
+			}
+		})
+	}
+}
+
+func TestDecimalAllocate(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		a    string
+		w    []string
+		prec uint8
+		r    string
+		e    string
+	}
+
+	testCases := [...]testCase{
+		{"100", []string{"1", "1", "1"}, 2, "33.34 33.33 33.33", ""},
+		{"10", []string{"0.7", "0.2", "0.1"}, 0, "7 2 1", ""},
+		{"0.05", []string{"3", "7"}, 2, "0.02 0.03", ""},
+		{"100", []string{"1", "2", "3"}, 2, "16.67 33.33 50", ""},
+		{"-100", []string{"1", "2", "3"}, 2, "-16.67 -33.33 -50", ""},
+		{"1", []string{"0", "1", "1", "1"}, 2, "0 0.34 0.33 0.33", ""},
+		{"0.01", []string{"1", "2", "1"}, 2, "0 0.01 0", ""},
+		{"-0.02", []string{"1", "0"}, 2, "-0.02 0", ""},
+		{"-0.01", []string{"1", "2", "1"}, 2, "0 -0.01 0", ""},
+		{"1000", []string{"0.333", "0.333", "0.334"}, 2, "333 333 334", ""},
+		{"99999999999999999999.99", []string{"1", "1", "1"}, 2, "33333333333333333333.33 33333333333333333333.33 33333333333333333333.33", ""},
+		{"1", []string{"0", "0"}, 2, "", "division by zero"},
+		{"1", []string{}, 2, "", "division by zero"},
+		{"1", []string{"1", "-1"}, 2, "", "negative value in unsigned operation"},
+		{"1.005", []string{"1", "1"}, 2, "", "rescale to less precision"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("TestDecimalAllocate(%s, %v, %d)", tc.a, tc.w, tc.prec), func(t *testing.T) {
+			weights := make([]dec128.Dec128, len(tc.w))
+			for i, w := range tc.w {
+				weights[i] = dec128.FromString(w)
+			}
+
+			parts, err := dec128.FromString(tc.a).Allocate(weights, tc.prec)
+			if tc.e != "" {
+				if err == nil || err.Error() != tc.e {
+					t.Errorf("expected error %q, got %v", tc.e, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if s := joinDecimals(parts); s != tc.r {
+				t.Errorf("expected %s, got %s", tc.r, s)
+			}
+			if sum := dec128.Sum(dec128.Zero, parts...); !sum.Equal(dec128.FromString(tc.a)) {
+				t.Errorf("expected parts to sum %s, got %s", tc.a, sum)
+			}
+			for i, p := range parts {
+				if p.IsZero() && (p.IsNegative() || !p.Equal(dec128.Zero)) {
+					t.Errorf("expected part %d to be zero, got a negative zero", i)
+				}
+			}
+		})
+	}
+}
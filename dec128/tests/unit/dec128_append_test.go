@@ -0,0 +1,128 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+)
+
+func TestDecimalAppend(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		d     dec128.Dec128
+		text  string
+		fixed string
+	}
+
+	testCases := [...]testCase{
+		{dec128.Zero, "0", "0"},
+		{dec128.FromString("0.00"), "0", "0.00"},
+		{dec128.FromString("1.10"), "1.1", "1.10"},
+		{dec128.FromString("-12345.000"), "-12345", "-12345.000"},
+		{dec128.FromString("12345678901234567890.123456789"), "12345678901234567890.123456789", "12345678901234567890.123456789"},
+		{dec128.FromString("-0.0000000000000000001"), "-0.0000000000000000001", "-0.0000000000000000001"},
+		{dec128.NaN(0), "NaN", "NaN"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			prefix := []byte("x=")
+
+			b, err := tc.d.AppendText(prefix)
+			if err != nil || string(b) != "x="+tc.text {
+				t.Errorf("AppendText: expected x=%s, got %s (%v)", tc.text, b, err)
+			}
+
+			b, err = tc.d.AppendJSON(prefix)
+			if err != nil || string(b) != `x="`+tc.text+`"` {
+				t.Errorf("AppendJSON: expected x=\"%s\", got %s (%v)", tc.text, b, err)
+			}
+
+			j, _ := tc.d.MarshalJSON()
+			if string(j) != string(b[len(prefix):]) {
+				t.Errorf("AppendJSON: expected the same as MarshalJSON %s, got %s", j, b[len(prefix):])
+			}
+
+			if b = tc.d.AppendStringFixed(prefix); string(b) != "x="+tc.fixed {
+				t.Errorf("AppendStringFixed: expected x=%s, got %s", tc.fixed, b)
+			}
+
+			if tc.d.StringFixed() != tc.fixed {
+				t.Errorf("StringFixed: expected %s, got %s", tc.fixed, tc.d.StringFixed())
+			}
+		})
+	}
+}
+
+func TestDecimalNewFromBytes(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	d, err := dec128.NewFromBytes([]byte("-12345678901234567890.123456789"))
+	if err != nil || d.String() != "-12345678901234567890.123456789" {
+		t.Errorf("expected -12345678901234567890.123456789, got %s (%v)", d, err)
+	}
+
+	if _, err := dec128.NewFromBytes([]byte("1.2.3")); err != dec128.ErrInvalidDecimal {
+		t.Errorf("expected %v, got %v", dec128.ErrInvalidDecimal, err)
+	}
+}
+
+func TestDecimalAppendAllocs(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	values := [...]dec128.Dec128{
+		dec128.Zero,
+		dec128.FromString("12345.67"),
+		dec128.FromString("-1234567890.123456789"),
+		dec128.FromString("123456789012345678901234567890.12"),
+	}
+	inputs := [...][]byte{
+		[]byte("12345.67"),
+		[]byte("-1234567890.123456789"),
+		[]byte("123456789012345678901234567890.12"),
+		[]byte(`"-1234567890.123456789"`),
+	}
+
+	buf := make([]byte, 0, 2*dec128.MaxStrLen)
+	var d dec128.Dec128
+
+	checks := map[string]func(){
+		"AppendText": func() {
+			for _, v := range values {
+				buf, _ = v.AppendText(buf[:0])
+			}
+		},
+		"AppendJSON": func() {
+			for _, v := range values {
+				buf, _ = v.AppendJSON(buf[:0])
+			}
+		},
+		"AppendStringFixed": func() {
+			for _, v := range values {
+				buf = v.AppendStringFixed(buf[:0])
+			}
+		},
+		"NewFromBytes": func() {
+			for _, in := range inputs[:3] {
+				d, _ = dec128.NewFromBytes(in)
+			}
+		},
+		"UnmarshalText": func() {
+			for _, in := range inputs[:3] {
+				_ = d.UnmarshalText(in)
+			}
+		},
+		"UnmarshalJSON": func() {
+			for _, in := range inputs {
+				_ = d.UnmarshalJSON(in)
+			}
+		},
+	}
+
+	for name, f := range checks {
+		if allocs := testing.AllocsPerRun(100, f); allocs != 0 {
+			t.Errorf("%s: expected 0 allocs, got %v", name, allocs)
+		}
+	}
+}
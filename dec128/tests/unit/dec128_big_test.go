@@ -0,0 +1,217 @@
+package unit
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+)
+
+func TestDecimalBigRat(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		a    string
+		want string
+	}
+
+	testCases := [...]testCase{
+		{"0", "0/1"},
+		{"1", "1/1"},
+		{"-1.25", "-5/4"},
+		{"0.0000000000000000001", "1/10000000000000000000"},
+		{"123456789012345678901234567.89", "12345678901234567890123456789/100"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("TestDecimalBigRat(%s)", tc.a), func(t *testing.T) {
+			r, err := dec128.FromString(tc.a).BigRat()
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if r.String() != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, r.String())
+			}
+
+			a := dec128.FromString(tc.a)
+			if d := dec128.FromBigRat(r, a.Precision(), dec128.RoundingTowardZero); !d.Equal(a) {
+				t.Errorf("FromBigRat: expected %s, got %s", tc.a, d.String())
+			}
+		})
+	}
+
+	if _, err := dec128.NaN(2).BigRat(); err == nil {
+		t.Errorf("expected an error for NaN")
+	}
+}
+
+func TestDecimalFromBigRat(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		r    string
+		prec uint8
+		mode dec128.RoundingMode
+		want string
+		e    string
+	}
+
+	testCases := [...]testCase{
+		{"1/3", 19, dec128.RoundingHalfAwayFromZero, "0.3333333333333333333", ""},
+		{"2/3", 19, dec128.RoundingHalfAwayFromZero, "0.6666666666666666667", ""},
+		{"2/3", 19, dec128.RoundingDown, "0.6666666666666666666", ""},
+		{"-2/3", 2, dec128.RoundingDown, "-0.67", ""},
+		{"-2/3", 2, dec128.RoundingTowardZero, "-0.66", ""},
+		{"5/2", 0, dec128.RoundingHalfEven, "2", ""},
+		{"7/2", 0, dec128.RoundingHalfEven, "4", ""},
+		{"5/2", 0, dec128.RoundingHalfTowardZero, "2", ""},
+		{"1/1000", 2, dec128.RoundingUp, "0.01", ""},
+		{"1/1000", 2, dec128.RoundingHalfAwayFromZero, "0", ""},
+		{"340282366920938463463374607431768211456/1", 0, dec128.RoundingHalfAwayFromZero, "", "overflow"},
+		{"1/3", 20, dec128.RoundingHalfAwayFromZero, "", "precision out of range"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("TestDecimalFromBigRat(%s, %d, %d)", tc.r, tc.prec, tc.mode), func(t *testing.T) {
+			r, _ := new(big.Rat).SetString(tc.r)
+			d := dec128.FromBigRat(r, tc.prec, tc.mode)
+
+			if tc.e != "" {
+				if !d.IsNaN() || d.ErrorDetails().Error() != tc.e {
+					t.Errorf("expected %s, got %s", tc.e, d.String())
+				}
+				return
+			}
+
+			if d.String() != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, d.String())
+			}
+		})
+	}
+
+	if d := dec128.FromBigRat(nil, 2, dec128.RoundingHalfEven); !d.IsNaN() {
+		t.Errorf("expected NaN for nil, got %s", d.String())
+	}
+}
+
+func TestDecimalBigFloat(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	f, err := dec128.FromString("0.1").BigFloat(53)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if v, _ := f.Float64(); v != 0.1 {
+		t.Errorf("expected 0.1, got %v", v)
+	}
+
+	f, _ = dec128.FromString("-340282366920938463463374607431768211455").BigFloat(0)
+	if f.Text('f', 0) != "-340282366920938463463374607431768211455" {
+		t.Errorf("expected an exact integer, got %s", f.Text('f', 0))
+	}
+
+	type testCase struct {
+		f    string
+		prec uint8
+		mode dec128.RoundingMode
+		want string
+		e    string
+	}
+
+	testCases := [...]testCase{
+		{"0.5", 19, dec128.RoundingHalfEven, "0.5", ""},
+		{"0.1", 19, dec128.RoundingHalfEven, "0.1", ""},
+		{"0.1", 19, dec128.RoundingDown, "0.1", ""},
+		{"-1.125", 2, dec128.RoundingHalfEven, "-1.12", ""},
+		{"-1.125", 2, dec128.RoundingHalfAwayFromZero, "-1.13", ""},
+		{"1e-300", 19, dec128.RoundingUp, "0.0000000000000000001", ""},
+		{"-1e-300", 19, dec128.RoundingUp, "0", ""},
+		{"-1e-300", 19, dec128.RoundingDown, "-0.0000000000000000001", ""},
+		{"1e38", 0, dec128.RoundingHalfEven, "100000000000000000000000000000000000000", ""},
+		{"1e39", 0, dec128.RoundingHalfEven, "", "overflow"},
+		{"1e1000", 0, dec128.RoundingHalfEven, "", "overflow"},
+		{"+Inf", 0, dec128.RoundingHalfEven, "", "overflow"},
+		{"-Inf", 0, dec128.RoundingHalfEven, "", "underflow"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("TestDecimalFromBigFloat(%s, %d, %d)", tc.f, tc.prec, tc.mode), func(t *testing.T) {
+			f, _, err := big.ParseFloat(tc.f, 10, 256, big.ToNearestEven)
+			if err != nil {
+				t.Fatalf("invalid float %s", tc.f)
+			}
+
+			d := dec128.FromBigFloat(f, tc.prec, tc.mode)
+
+			if tc.e != "" {
+				if !d.IsNaN() || d.ErrorDetails().Error() != tc.e {
+					t.Errorf("expected %s, got %s", tc.e, d.String())
+				}
+				return
+			}
+
+			if d.String() != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, d.String())
+			}
+		})
+	}
+
+	// the float64 nearest to 0.1 is 0.1000000000000000055511151231257827..., the one below it 0.0999999999999999916733...
+	d := dec128.FromBigFloat(big.NewFloat(0.1), 19, dec128.RoundingHalfEven)
+	if d.String() != "0.1000000000000000056" {
+		t.Errorf("expected 0.1000000000000000056, got %s", d.String())
+	}
+	if d = dec128.FromBigFloat(big.NewFloat(math.Nextafter(0.1, 0)), 19, dec128.RoundingDown); d.String() != "0.0999999999999999916" {
+		t.Errorf("expected 0.0999999999999999916, got %s", d.String())
+	}
+}
+
+func TestDecimalBigInt(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		a    string
+		exp  uint8
+		want string
+	}
+
+	testCases := [...]testCase{
+		{"0", 2, "0"},
+		{"12.345", 3, "12345"},
+		{"-12.345", 5, "-1234500"},
+		{"-12.345", 1, "-123"},
+		{"340282366920938463463374607431768211455", 19, "3402823669209384634633746074317682114550000000000000000000"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("TestDecimalBigInt(%s, %d)", tc.a, tc.exp), func(t *testing.T) {
+			x, err := dec128.FromString(tc.a).EncodeToBigInt(tc.exp)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if x.String() != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, x.String())
+			}
+		})
+	}
+
+	x, _ := new(big.Int).SetString("-1234500", 10)
+	if d := dec128.DecodeFromBigInt(x, 5); d.String() != "-12.345" {
+		t.Errorf("expected -12.345, got %s", d.String())
+	}
+
+	x.Lsh(x, 128)
+	if d := dec128.DecodeFromBigInt(x, 5); !d.IsNaN() || d.ErrorDetails().Error() != "overflow" {
+		t.Errorf("expected overflow, got %s", d.String())
+	}
+
+	if d := dec128.DecodeFromBigInt(big.NewInt(1), 20); !d.IsNaN() || d.ErrorDetails().Error() != "precision out of range" {
+		t.Errorf("expected precision out of range, got %s", d.String())
+	}
+
+	if _, err := dec128.One.EncodeToBigInt(20); err == nil {
+		t.Errorf("expected precision out of range")
+	}
+}
@@ -0,0 +1,188 @@
+package unit
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/dec128/errors"
+	"github.com/profe-ajedrez/badassitron/dec128/uint128"
+)
+
+func TestDecimalBinary(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		d  dec128.Dec128
+		sz int
+	}
+
+	testCases := [...]testCase{
+		{dec128.Zero, 3},
+		{dec128.FromString("0.00"), 3},
+		{dec128.FromString("1"), 3},
+		{dec128.FromString("-1"), 3},
+		{dec128.FromString("127"), 3},
+		{dec128.FromString("128"), 4},
+		{dec128.FromString("123.45"), 4},
+		{dec128.FromString("-123.45"), 4},
+		{dec128.FromString("0.0000000000000000001"), 3},
+		{dec128.FromString("18446744073709551615"), 12},
+		{dec128.FromString("18446744073709551616"), 12},
+		{dec128.FromString("-12345678901234567890.123456789"), 16},
+		{dec128.New(uint128.Max, 19, true), 21},
+		{dec128.NaN(errors.DivisionByZero), 3},
+		{dec128.NaN(errors.Overflow), 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("TestDecimalBinary(%s)", tc.d.StringFixed()), func(t *testing.T) {
+			b, err := tc.d.MarshalBinary()
+			if err != nil {
+				t.Fatalf("MarshalBinary: %v", err)
+			}
+			if len(b) != tc.sz {
+				t.Errorf("expected %d bytes, got %d: %x", tc.sz, len(b), b)
+			}
+
+			var d dec128.Dec128
+			if err := d.UnmarshalBinary(b); err != nil {
+				t.Fatalf("UnmarshalBinary(%x): %v", b, err)
+			}
+			if d.StringFixed() != tc.d.StringFixed() || d.Precision() != tc.d.Precision() || d.ErrorDetails() != tc.d.ErrorDetails() {
+				t.Errorf("expected %s, got %s", tc.d.StringFixed(), d.StringFixed())
+			}
+
+			prefix := []byte("prefix")
+			a, err := tc.d.AppendBinary(prefix)
+			if err != nil {
+				t.Fatalf("AppendBinary: %v", err)
+			}
+			if !bytes.Equal(a[:len(prefix)], prefix) || !bytes.Equal(a[len(prefix):], b) {
+				t.Errorf("AppendBinary: expected prefix followed by %x, got %x", b, a)
+			}
+		})
+	}
+}
+
+func TestDecimalUnmarshalBinaryErrors(t *testing.T) {
+	type testCase struct {
+		b []byte
+		e string
+	}
+
+	testCases := [...]testCase{
+		{nil, "not enough bytes"},
+		{[]byte{1, 0}, "not enough bytes"},
+		{[]byte{2, 0, 1}, "invalid format"},
+		{[]byte{1, 0x80, 1}, "invalid format"},
+		{[]byte{1, 20, 1}, "precision out of range"},
+		{[]byte{1, 0, 0x81}, "not enough bytes"},
+		{[]byte{1, 0, 1, 1}, "invalid format"},
+		{[]byte{1, 0x40, 0}, "invalid format"},
+		{[]byte{1, 0x40, 200}, "invalid format"},
+		{[]byte{1, 0x41, 2}, "invalid format"},
+	}
+
+	for _, tc := range testCases {
+		var d dec128.Dec128
+		if err := d.UnmarshalBinary(tc.b); err == nil || err.Error() != tc.e {
+			t.Errorf("UnmarshalBinary(%x): expected error %q, got %v", tc.b, tc.e, err)
+		}
+	}
+}
+
+func TestDecimalGob(t *testing.T) {
+	type testStruct struct {
+		A dec128.Dec128
+		B dec128.Dec128
+		U uint128.Uint128
+	}
+
+	in := testStruct{dec128.FromString("-1234.5678"), dec128.NaN(errors.Underflow), uint128.Max}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+
+	var out testStruct
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	if !out.A.Equal(in.A) || out.B.ErrorDetails() != in.B.ErrorDetails() || !out.U.Equal(in.U) {
+		t.Errorf("expected %+v, got %+v", in, out)
+	}
+}
+
+func TestDecimalAppendText(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	for _, s := range []string{"0", "1", "-1.01", "123.456", "0.000001", "12345678901234567890.123456789", "NaN"} {
+		d := dec128.FromString(s)
+		if s == "NaN" {
+			d = dec128.NaN(errors.NotANumber)
+		}
+
+		b, err := d.AppendText([]byte("x="))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != "x="+s {
+			t.Errorf("expected x=%s, got %s", s, b)
+		}
+	}
+
+	b, _ := dec128.FromString("1.500").AppendText(nil)
+	if string(b) != "1.5" {
+		t.Errorf("expected 1.5, got %s", b)
+	}
+}
+
+func TestUint128Binary(t *testing.T) {
+	testCases := [...]uint128.Uint128{
+		uint128.Zero,
+		uint128.One,
+		{Lo: 127},
+		{Lo: 128},
+		uint128.Max64,
+		{Lo: 0, Hi: 1},
+		{Lo: 1 << 63, Hi: 1 << 62},
+		uint128.Max,
+	}
+
+	for _, u := range testCases {
+		b, err := u.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var v uint128.Uint128
+		if err := v.UnmarshalBinary(b); err != nil {
+			t.Fatalf("UnmarshalBinary(%x): %v", b, err)
+		}
+		if !v.Equal(u) {
+			t.Errorf("expected %s, got %s", u, v)
+		}
+
+		txt, _ := u.AppendText([]byte("u="))
+		if string(txt) != "u="+u.String() {
+			t.Errorf("expected u=%s, got %s", u, txt)
+		}
+	}
+
+	if b, _ := uint128.Max.MarshalBinary(); len(b) != 20 {
+		t.Errorf("expected 20 bytes for max uint128, got %d", len(b))
+	}
+
+	// 19 groups of 7 bits with the last one above the 2 remaining bits
+	overflow := append([]byte{1}, bytes.Repeat([]byte{0xff}, 18)...)
+	overflow = append(overflow, 0x04)
+	var v uint128.Uint128
+	if err := v.UnmarshalBinary(overflow); err == nil || err.Error() != "overflow" {
+		t.Errorf("expected overflow, got %v", err)
+	}
+}
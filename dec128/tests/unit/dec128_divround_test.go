@@ -0,0 +1,164 @@
+package unit
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+)
+
+func TestDecimalDivRound(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		a       string
+		b       string
+		prec    uint8
+		mode    dec128.RoundingMode
+		want    string
+		inexact bool
+		e       string
+	}
+
+	testCases := [...]testCase{
+		{"2", "3", 2, dec128.RoundingHalfAwayFromZero, "0.67", true, ""},
+		{"2", "3", 2, dec128.RoundingTowardZero, "0.66", true, ""},
+		{"-2", "3", 2, dec128.RoundingDown, "-0.67", true, ""},
+		{"-2", "3", 2, dec128.RoundingUp, "-0.66", true, ""},
+		{"1", "4", 2, dec128.RoundingHalfEven, "0.25", false, ""},
+		{"1", "8", 2, dec128.RoundingHalfEven, "0.12", true, ""},
+		{"3", "8", 2, dec128.RoundingHalfEven, "0.38", true, ""},
+		{"1", "8", 2, dec128.RoundingHalfTowardZero, "0.12", true, ""},
+		{"1", "8", 2, dec128.RoundingHalfAwayFromZero, "0.13", true, ""},
+		{"1", "3", 19, dec128.RoundingHalfAwayFromZero, "0.3333333333333333333", true, ""},
+		{"1", "3", 0, dec128.RoundingAwayFromZero, "1", true, ""},
+		{"1", "3", 0, dec128.RoundingHalfAwayFromZero, "0", true, ""},
+		{"1.2345", "0.5", 2, dec128.RoundingHalfAwayFromZero, "2.47", true, ""},
+		{"0.0000000000000000001", "340282366920938463463374607431768211455", 19, dec128.RoundingUp, "0.0000000000000000001", true, ""},
+		{"1.2345", "340282366920938463463374607431768211455", 0, dec128.RoundingHalfEven, "0", true, ""},
+		{"340282366920938463463374607431768211455", "0.5", 0, dec128.RoundingHalfEven, "", false, "overflow"},
+		{"1", "0", 2, dec128.RoundingHalfEven, "", false, "division by zero"},
+		{"1", "3", 20, dec128.RoundingHalfEven, "", false, "precision out of range"},
+		{"1", "3", 2, dec128.RoundingMode(99), "", false, "invalid format"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("TestDecimalDivRound(%s, %s, %d, %d)", tc.a, tc.b, tc.prec, tc.mode), func(t *testing.T) {
+			d, inexact := dec128.FromString(tc.a).DivRound(dec128.FromString(tc.b), tc.prec, tc.mode)
+
+			if tc.e != "" {
+				if !d.IsNaN() || d.ErrorDetails().Error() != tc.e {
+					t.Errorf("expected %s, got %s", tc.e, d.String())
+				}
+				return
+			}
+
+			if d.String() != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, d.String())
+			}
+
+			if inexact != tc.inexact {
+				t.Errorf("expected inexact %t, got %t", tc.inexact, inexact)
+			}
+		})
+	}
+
+	if d, _ := dec128.FromInt(7).DivIntRound(2, 0, dec128.RoundingHalfEven); d.String() != "4" {
+		t.Errorf("DivIntRound: expected 4, got %s", d.String())
+	}
+}
+
+func TestDecimalDivRoundRandom(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	rnd := rand.New(rand.NewSource(2))
+
+	for range 20000 {
+		a := randomDecimal(rnd, 1+rnd.Intn(100))
+		b := randomDecimal(rnd, 1+rnd.Intn(100))
+		if b.IsZero() {
+			continue
+		}
+
+		prec := uint8(rnd.Intn(int(dec128.MaxPrecision) + 1))
+		mode := dec128.RoundingMode(rnd.Intn(int(dec128.RoundingAwayFromZero) + 1))
+
+		x, _ := a.BigRat()
+		y, _ := b.BigRat()
+		want := dec128.FromBigRat(x.Quo(x, y), prec, mode)
+
+		d, inexact := a.DivRound(b, prec, mode)
+		if !d.Equal(want) {
+			t.Fatalf("%s / %s (%d, %d): expected %s, got %s", a, b, prec, mode, want, d)
+		}
+
+		if d.IsNaN() {
+			continue
+		}
+
+		q, r := a.QuoRemRound(b, prec, mode)
+		if !q.Equal(d) {
+			t.Fatalf("QuoRemRound(%s, %s): expected quotient %s, got %s", a, b, d, q)
+		}
+
+		if inexact != !r.IsZero() && !r.IsNaN() {
+			t.Fatalf("%s / %s (%d, %d): inexact %t with remainder %s", a, b, prec, mode, inexact, r)
+		}
+
+		if r.IsNaN() {
+			continue
+		}
+
+		qr, _ := q.BigRat()
+		br, _ := b.BigRat()
+		rr, _ := r.BigRat()
+		ar, _ := a.BigRat()
+		if qr.Mul(qr, br).Add(qr, rr).Cmp(ar) != 0 {
+			t.Fatalf("QuoRemRound(%s, %s): %s * %s + %s != %s", a, b, q, b, r, a)
+		}
+	}
+}
+
+func TestDecimalQuoRemRound(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		a    string
+		b    string
+		prec uint8
+		mode dec128.RoundingMode
+		q    string
+		r    string
+	}
+
+	testCases := [...]testCase{
+		{"10", "3", 2, dec128.RoundingHalfAwayFromZero, "3.33", "0.01"},
+		{"20", "3", 2, dec128.RoundingHalfAwayFromZero, "6.67", "-0.01"},
+		{"-20", "3", 2, dec128.RoundingTowardZero, "-6.66", "-0.02"},
+		{"10", "4", 2, dec128.RoundingHalfEven, "2.5", "0"},
+		{"10.5", "0.25", 0, dec128.RoundingDown, "42", "0"},
+		{"10.6", "0.25", 0, dec128.RoundingDown, "42", "0.1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("TestDecimalQuoRemRound(%s, %s, %d, %d)", tc.a, tc.b, tc.prec, tc.mode), func(t *testing.T) {
+			q, r := dec128.FromString(tc.a).QuoRemRound(dec128.FromString(tc.b), tc.prec, tc.mode)
+			if q.String() != tc.q {
+				t.Errorf("expected quotient %s, got %s", tc.q, q.String())
+			}
+			if r.String() != tc.r {
+				t.Errorf("expected remainder %s, got %s", tc.r, r.String())
+			}
+		})
+	}
+
+	// 1 / 0.03 = 33.3333333333333333333 + 1E-21, the remainder has too many decimals
+	q, r := dec128.One.QuoRemRound(dec128.FromString("0.03"), 19, dec128.RoundingTowardZero)
+	if q.String() != "33.3333333333333333333" {
+		t.Errorf("expected quotient 33.3333333333333333333, got %s", q.String())
+	}
+	if !r.IsNaN() || r.ErrorDetails().Error() != "precision out of range" {
+		t.Errorf("expected precision out of range, got %s", r.String())
+	}
+}
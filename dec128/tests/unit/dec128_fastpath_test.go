@@ -0,0 +1,105 @@
+package unit
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/dec128/uint128"
+)
+
+// randomDecimal returns a decimal with a coefficient of up to bits bits and a random exponent and sign.
+func randomDecimal(rnd *rand.Rand, bits int) dec128.Dec128 {
+	coef := uint128.Uint128{Lo: rnd.Uint64()}
+	if bits > 64 {
+		coef.Hi = rnd.Uint64() >> (128 - bits)
+	} else {
+		coef.Lo >>= 64 - bits
+	}
+
+	return dec128.New(coef, uint8(rnd.Intn(int(dec128.MaxPrecision)+1)), rnd.Intn(2) == 0)
+}
+
+// bigDecimal returns the decimal as a big.Int scaled by 10^prec.
+func bigDecimal(d dec128.Dec128, prec uint8) *big.Int {
+	x := d.Coefficient().BigInt()
+	x.Mul(x, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(prec-d.Exponent())), nil))
+	if d.IsNegative() {
+		x.Neg(x)
+	}
+	return x
+}
+
+// checkBigDecimal checks that d is x scaled by 10^prec, both in value and in precision.
+// Results that don't fit at prec are skipped, the operations retry them with canonical operands.
+func checkBigDecimal(t *testing.T, op string, a, b, d dec128.Dec128, x *big.Int, prec uint8) {
+	t.Helper()
+
+	if x.BitLen() > 128 {
+		return
+	}
+
+	if d.IsNaN() {
+		t.Errorf("%s %s %s: unexpected %s", a, op, b, d.ErrorDetails())
+		return
+	}
+
+	if d.Precision() != prec {
+		t.Errorf("%s %s %s: expected precision %d, got %d", a, op, b, prec, d.Precision())
+	}
+
+	if got := bigDecimal(d, prec); got.Cmp(x) != 0 {
+		t.Errorf("%s %s %s: expected %s, got %s", a, op, b, x, got)
+	}
+}
+
+func TestDecimalArithmeticPaths(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	rnd := rand.New(rand.NewSource(1))
+	ten := big.NewInt(10)
+
+	for i := range 20000 {
+		// mix operands taking the 64-bit fast paths with operands taking the 128-bit ones
+		abits, bbits := 1+rnd.Intn(64), 1+rnd.Intn(64)
+		if i%4 == 3 {
+			abits = 65 + rnd.Intn(40)
+		}
+
+		a := randomDecimal(rnd, abits)
+		b := randomDecimal(rnd, bbits)
+		if i%2 == 0 {
+			b = dec128.New(b.Coefficient(), a.Exponent(), b.IsNegative())
+		}
+
+		if a.IsZero() || b.IsZero() {
+			continue
+		}
+
+		prec := max(a.Exponent(), b.Exponent())
+		if r := a.Add(b); !r.IsZero() {
+			x := new(big.Int).Add(bigDecimal(a, prec), bigDecimal(b, prec))
+			checkBigDecimal(t, "+", a, b, r, x, prec)
+		}
+
+		if r := a.Sub(b); !r.IsZero() {
+			x := new(big.Int).Sub(bigDecimal(a, prec), bigDecimal(b, prec))
+			checkBigDecimal(t, "-", a, b, r, x, prec)
+		}
+
+		if prec = a.Exponent() + b.Exponent(); prec <= dec128.MaxPrecision && abits+bbits <= 128 {
+			x := new(big.Int).Mul(bigDecimal(a, a.Exponent()), bigDecimal(b, b.Exponent()))
+			checkBigDecimal(t, "*", a, b, a.Mul(b), x, prec)
+		}
+
+		if prec = max(a.Exponent(), 19); abits <= 64 {
+			x := bigDecimal(a, a.Exponent())
+			x.Mul(x, new(big.Int).Exp(ten, big.NewInt(int64(prec-a.Exponent()+b.Exponent())), nil))
+			x.Quo(x, bigDecimal(b, b.Exponent()))
+			if x.Sign() != 0 {
+				checkBigDecimal(t, "/", a, b, a.Div(b), x, prec)
+			}
+		}
+	}
+}
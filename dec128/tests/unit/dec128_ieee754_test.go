@@ -0,0 +1,177 @@
+package unit
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/dec128/uint128"
+)
+
+func TestDecimalIEEE754Encode(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		a   string
+		bid uint128.Uint128
+		dpd uint128.Uint128
+	}
+
+	testCases := [...]testCase{
+		{"0", uint128.Uint128{Hi: 0x3040000000000000}, uint128.Uint128{Hi: 0x2208000000000000}},
+		{"1", uint128.Uint128{Lo: 1, Hi: 0x3040000000000000}, uint128.Uint128{Lo: 1, Hi: 0x2208000000000000}},
+		{"-1", uint128.Uint128{Lo: 1, Hi: 0xb040000000000000}, uint128.Uint128{Lo: 1, Hi: 0xa208000000000000}},
+		{"1.00", uint128.Uint128{Lo: 100, Hi: 0x303c000000000000}, uint128.Uint128{Lo: 0x80, Hi: 0x2207800000000000}},
+		{"123.45", uint128.Uint128{Lo: 12345, Hi: 0x303c000000000000}, uint128.Uint128{Lo: 0x49c5, Hi: 0x2207800000000000}},
+		{"0.999", uint128.Uint128{Lo: 999, Hi: 0x303a000000000000}, uint128.Uint128{Lo: 0xff, Hi: 0x2207400000000000}},
+		{"0.0000000000000000001", uint128.Uint128{Lo: 1, Hi: 0x301a000000000000}, uint128.Uint128{Lo: 1, Hi: 0x2203400000000000}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("TestDecimalIEEE754Encode(%s)", tc.a), func(t *testing.T) {
+			d := dec128.FromString(tc.a)
+
+			bid, err := d.BID()
+			if err != nil {
+				t.Fatalf("BID: unexpected error %v", err)
+			}
+			if bid != tc.bid {
+				t.Errorf("BID: expected %016x%016x, got %016x%016x", tc.bid.Hi, tc.bid.Lo, bid.Hi, bid.Lo)
+			}
+
+			dpd, err := d.DPD()
+			if err != nil {
+				t.Fatalf("DPD: unexpected error %v", err)
+			}
+			if dpd != tc.dpd {
+				t.Errorf("DPD: expected %016x%016x, got %016x%016x", tc.dpd.Hi, tc.dpd.Lo, dpd.Hi, dpd.Lo)
+			}
+
+			if r := dec128.FromBID(bid); !r.Equal(d) {
+				t.Errorf("FromBID: expected %s, got %s", d.String(), r.String())
+			}
+
+			if r := dec128.FromDPD(dpd); !r.Equal(d) {
+				t.Errorf("FromDPD: expected %s, got %s", d.String(), r.String())
+			}
+		})
+	}
+}
+
+func TestDecimalIEEE754Decode(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		name string
+		bid  uint128.Uint128
+		dpd  uint128.Uint128
+		want string
+		e    string
+	}
+
+	testCases := [...]testCase{
+		{"+Inf", uint128.Uint128{Hi: 0x7800000000000000}, uint128.Uint128{Hi: 0x7800000000000000}, "", "overflow"},
+		{"-Inf", uint128.Uint128{Hi: 0xf800000000000000}, uint128.Uint128{Hi: 0xf800000000000000}, "", "underflow"},
+		{"qNaN", uint128.Uint128{Hi: 0x7c00000000000000}, uint128.Uint128{Hi: 0x7c00000000000000}, "", "not a number"},
+		{"sNaN", uint128.Uint128{Hi: 0x7e00000000000000}, uint128.Uint128{Hi: 0x7e00000000000000}, "", "not a number"},
+		{"NaN(2)", uint128.Uint128{Lo: 2, Hi: 0x7c00000000000000}, uint128.Uint128{Lo: 2, Hi: 0x7c00000000000000}, "", "division by zero"},
+		{"NaN(12345)", uint128.Uint128{Lo: 12345, Hi: 0x7c00000000000000}, uint128.Uint128{Lo: 0x49c5, Hi: 0x7c00000000000000}, "", "not a number"},
+		{
+			"9.999999999999999999999999999999999E+6144",
+			uint128.Uint128{Lo: 0x378d8e63ffffffff, Hi: 0x5fffed09bead87c0},
+			uint128.Uint128{Lo: 0xf3fcff3fcff3fcff, Hi: 0x77ffcff3fcff3fcf},
+			"", "overflow",
+		},
+		{"1E+39", uint128.Uint128{Lo: 1, Hi: 0x308e000000000000}, uint128.Uint128{Lo: 1, Hi: 0x2211c00000000000}, "", "overflow"},
+		{"1E-20", uint128.Uint128{Lo: 1, Hi: 0x3018000000000000}, uint128.Uint128{Lo: 1, Hi: 0x2203000000000000}, "", "underflow"},
+		{"10E-20", uint128.Uint128{Lo: 10, Hi: 0x3018000000000000}, uint128.Uint128{Lo: 0x10, Hi: 0x2203000000000000}, "0.0000000000000000001", ""},
+		{"1E-6176", uint128.Uint128{Lo: 1}, uint128.Uint128{Lo: 1}, "", "underflow"},
+		{"0E-6176", uint128.Uint128{}, uint128.Uint128{}, "0", ""},
+		{"-0", uint128.Uint128{Hi: 0xb040000000000000}, uint128.Uint128{Hi: 0xa208000000000000}, "0", ""},
+		{"non-canonical", uint128.Uint128{Lo: 0xffffffffffffffff, Hi: 0x3041ffffffffffff}, uint128.Uint128{Hi: 0x2208000000000000}, "0", ""},
+		{"non-canonical 11 form", uint128.Uint128{Lo: 1, Hi: 0x6c10000000000000}, uint128.Uint128{Hi: 0x2208000000000000}, "0", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("TestDecimalIEEE754Decode(%s)", tc.name), func(t *testing.T) {
+			for _, d := range [...]dec128.Dec128{dec128.FromBID(tc.bid), dec128.FromDPD(tc.dpd)} {
+				if tc.e != "" {
+					if !d.IsNaN() || d.ErrorDetails().Error() != tc.e {
+						t.Errorf("expected %s, got %s", tc.e, d.String())
+					}
+					continue
+				}
+
+				if d.String() != tc.want {
+					t.Errorf("expected %s, got %s", tc.want, d.String())
+				}
+			}
+		})
+	}
+}
+
+func TestDecimalIEEE754RoundTrip(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	// every declet
+	for i := range 1000 {
+		d := dec128.FromInt(i * 1001)
+		dpd, err := d.DPD()
+		if err != nil {
+			t.Fatalf("DPD(%d): unexpected error %v", i, err)
+		}
+		if r := dec128.FromDPD(dpd); !r.Equal(d) {
+			t.Errorf("FromDPD(%d): expected %s, got %s", i, d.String(), r.String())
+		}
+	}
+
+	for _, s := range [...]string{
+		"1234567890123456789.012345678901234",
+		"-9999999999999999999999999999999999",
+		"98765432109876543210987654321098.76",
+		"-0.0000000000000001234",
+		"100000000000000000000000000000000000000",
+	} {
+		d := dec128.FromString(s)
+
+		bid, err := d.BIDBytes()
+		if err != nil {
+			t.Fatalf("BIDBytes(%s): unexpected error %v", s, err)
+		}
+		if r := dec128.FromBIDBytes(bid); r.String() != s {
+			t.Errorf("FromBIDBytes: expected %s, got %s", s, r.String())
+		}
+
+		dpd, err := d.DPDBytes()
+		if err != nil {
+			t.Fatalf("DPDBytes(%s): unexpected error %v", s, err)
+		}
+		if r := dec128.FromDPDBytes(dpd); r.String() != s {
+			t.Errorf("FromDPDBytes: expected %s, got %s", s, r.String())
+		}
+	}
+
+	// non-canonical declets read as 8 or 9 digits
+	if r := dec128.FromDPD(uint128.Uint128{Lo: 0x3ff, Hi: 0x2208000000000000}); r.String() != "999" {
+		t.Errorf("FromDPD: expected 999, got %s", r.String())
+	}
+
+	// more than 34 significant digits don't fit
+	d := dec128.FromString("12345678901234567890.1234567890123456789")
+	if _, err := d.BID(); err == nil || err.Error() != "precision out of range" {
+		t.Errorf("BID: expected precision out of range, got %v", err)
+	}
+	if _, err := d.DPD(); err == nil || err.Error() != "precision out of range" {
+		t.Errorf("DPD: expected precision out of range, got %v", err)
+	}
+
+	// NaN keeps its reason
+	nan := dec128.NaN(3)
+	bid, _ := nan.BID()
+	dpd, _ := nan.DPD()
+	for _, r := range [...]dec128.Dec128{dec128.FromBID(bid), dec128.FromDPD(dpd)} {
+		if !r.IsNaN() || r.ErrorDetails().Error() != "overflow" {
+			t.Errorf("expected overflow, got %s", r.String())
+		}
+	}
+}
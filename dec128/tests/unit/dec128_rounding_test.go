@@ -615,3 +615,81 @@ func TestDecimalTrunc(t *testing.T) {
 		})
 	}
 }
+
+func TestDecimalRoundWithMode(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		i string
+		p uint8
+		m dec128.RoundingMode
+		s string
+	}
+
+	testCases := [...]testCase{
+		{"1.235", 2, dec128.RoundingHalfAwayFromZero, "1.24"},
+		{"1.235", 2, dec128.RoundingHalfTowardZero, "1.23"},
+		{"1.225", 2, dec128.RoundingHalfEven, "1.22"},
+		{"-1.231", 2, dec128.RoundingDown, "-1.24"},
+		{"-1.239", 2, dec128.RoundingUp, "-1.23"},
+		{"1.239", 2, dec128.RoundingTowardZero, "1.23"},
+		{"1.231", 2, dec128.RoundingAwayFromZero, "1.24"},
+		{"1.231", 2, dec128.RoundingMode(100), "NaN"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("TestDecimalRoundWithMode(%v)", tc), func(t *testing.T) {
+			s := dec128.FromString(tc.i).RoundWithMode(tc.p, tc.m).StringFixed()
+			if s != tc.s {
+				t.Errorf("RoundWithMode(%v, %v, %v) = %v, want %v", tc.i, tc.p, tc.m, s, tc.s)
+			}
+		})
+	}
+}
+
+func TestDecimalRoundToIncrement(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		i   string
+		inc string
+		m   dec128.RoundingMode
+		s   string
+	}
+
+	testCases := [...]testCase{
+		{"1.024", "0.05", dec128.RoundingHalfAwayFromZero, "1"},
+		{"1.025", "0.05", dec128.RoundingHalfAwayFromZero, "1.05"},
+		{"1.074", "0.05", dec128.RoundingHalfAwayFromZero, "1.05"},
+		{"1.075", "0.05", dec128.RoundingHalfAwayFromZero, "1.1"},
+		{"-1.025", "0.05", dec128.RoundingHalfAwayFromZero, "-1.05"},
+		{"1231", "10", dec128.RoundingHalfTowardZero, "1230"},
+		{"1235", "10", dec128.RoundingHalfTowardZero, "1230"},
+		{"1236", "10", dec128.RoundingHalfTowardZero, "1240"},
+		{"1239", "10", dec128.RoundingHalfTowardZero, "1240"},
+		{"1240", "10", dec128.RoundingHalfTowardZero, "1240"},
+		{"1235.5", "10", dec128.RoundingHalfTowardZero, "1240"},
+		{"15", "10", dec128.RoundingHalfEven, "20"},
+		{"25", "10", dec128.RoundingHalfEven, "20"},
+		{"-1236", "10", dec128.RoundingDown, "-1240"},
+		{"1236", "10", dec128.RoundingDown, "1230"},
+		{"1231", "10", dec128.RoundingUp, "1240"},
+		{"-1239", "10", dec128.RoundingUp, "-1230"},
+		{"-1239", "10", dec128.RoundingTowardZero, "-1230"},
+		{"1231", "10", dec128.RoundingAwayFromZero, "1240"},
+		{"-0.02", "0.05", dec128.RoundingAwayFromZero, "-0.05"},
+		{"0.02", "0.05", dec128.RoundingHalfAwayFromZero, "0"},
+		{"7.3", "-0.5", dec128.RoundingHalfAwayFromZero, "7.5"},
+		{"7.3", "0", dec128.RoundingHalfAwayFromZero, "NaN"},
+		{"NaN", "0.05", dec128.RoundingHalfAwayFromZero, "NaN"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("TestDecimalRoundToIncrement(%v)", tc), func(t *testing.T) {
+			s := dec128.FromString(tc.i).RoundToIncrement(dec128.FromString(tc.inc), tc.m).String()
+			if s != tc.s {
+				t.Errorf("RoundToIncrement(%v, %v, %v) = %v, want %v", tc.i, tc.inc, tc.m, s, tc.s)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package unit
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+)
+
+func TestDecimalParseExtended(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		i string
+		s string
+		e string
+	}
+
+	testCases := [...]testCase{
+		{"1.5e-3", "0.0015", ""},
+		{"1.5E-3", "0.0015", ""},
+		{"1.5e3", "1500", ""},
+		{"1.5e+3", "1500", ""},
+		{"-2.5e0", "-2.5", ""},
+		{"+12", "12", ""},
+		{"+1.25e2", "125", ""},
+		{".5", "0.5", ""},
+		{"-.5e1", "-5", ""},
+		{"5.e2", "500", ""},
+		{"1e-19", "0.0000000000000000001", ""},
+		{"123e-21", "0.0000000000000000001", "underflow"},
+		{"100e-21", "0.0000000000000000001", ""},
+		{"1e38", "100000000000000000000000000000000000000", ""},
+		{"1e39", "NaN", "overflow"},
+		{"1e999999999999", "NaN", "overflow"},
+		{"1e-999999999999", "NaN", "underflow"},
+		{"0e999999999999", "0", ""},
+		{"-0.0e-5", "0", ""},
+		{"1_000.50", "1000.5", ""},
+		{"-1_234_567.891_2", "-1234567.8912", ""},
+		{"12_345_678_901_234_567_890.123_456", "12345678901234567890.123456", ""},
+		{"1_5e1_0", "NaN", "invalid format"},
+		{"1__000", "NaN", "invalid format"},
+		{"_1000", "NaN", "invalid format"},
+		{"1000_", "NaN", "invalid format"},
+		{"1_.5", "NaN", "invalid format"},
+		{"1,000", "NaN", "invalid format"},
+		{"e5", "NaN", "invalid format"},
+		{"1e", "NaN", "invalid format"},
+		{"1e+", "NaN", "invalid format"},
+		{"1e5.5", "NaN", "invalid format"},
+		{"1e5e5", "NaN", "invalid format"},
+		{".e5", "NaN", "invalid format"},
+		{"0.00000000000000000001_0", "NaN", "precision out of range"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("TestDecimalParseExtended(%s)", tc.i), func(t *testing.T) {
+			d := dec128.FromString(tc.i)
+			if tc.e != "" {
+				if !d.IsNaN() || d.ErrorDetails().Error() != tc.e {
+					t.Errorf("expected error %s, got %s", tc.e, d.String())
+				}
+				return
+			}
+			if d.String() != tc.s {
+				t.Errorf("expected %s, got %s", tc.s, d.String())
+			}
+		})
+	}
+}
+
+func TestDecimalDigitSeparators(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+	defer dec128.SetDigitSeparators("_")
+
+	dec128.SetDigitSeparators(",'")
+	if d := dec128.FromString("1,000'000.5"); d.String() != "1000000.5" {
+		t.Errorf("expected 1000000.5, got %s", d.String())
+	}
+
+	if d := dec128.FromString("1_000"); !d.IsNaN() {
+		t.Errorf("expected NaN, got %s", d.String())
+	}
+
+	dec128.SetDigitSeparators("")
+	if d := dec128.FromString("1,000"); !d.IsNaN() {
+		t.Errorf("expected NaN, got %s", d.String())
+	}
+
+	for _, seps := range [...]string{"1", ".", "-", "+", "e", "E"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for separator %q", seps)
+				}
+			}()
+			dec128.SetDigitSeparators(seps)
+		}()
+	}
+}
+
+func TestDecimalFromFloat64(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		f float64
+		s string
+	}
+
+	testCases := [...]testCase{
+		{0, "0"},
+		{900, "900"},
+		{144.5, "144.5"},
+		{-0.1, "-0.1"},
+		{0.30000000000000004, "0.30000000000000004"},
+		{1.5e-3, "0.0015"},
+		{1e30, "1000000000000000000000000000000"},
+		{1e-19, "0.0000000000000000001"},
+		{1e-25, "NaN"},
+		{1e40, "NaN"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("TestDecimalFromFloat64(%v)", tc.f), func(t *testing.T) {
+			if d := dec128.FromFloat64(tc.f); d.String() != tc.s {
+				t.Errorf("expected %s, got %s", tc.s, d.String())
+			}
+		})
+	}
+}
+
+func TestDecimalStringSci(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		i string
+		s string
+	}
+
+	testCases := [...]testCase{
+		{"0", "0e+00"},
+		{"0.000", "0e+00"},
+		{"1", "1e+00"},
+		{"-1", "-1e+00"},
+		{"1234.5", "1.2345e+03"},
+		{"1234.500", "1.2345e+03"},
+		{"1000", "1e+03"},
+		{"0.0015", "1.5e-03"},
+		{"-0.0000005", "-5e-07"},
+		{"0.0000000000000000001", "1e-19"},
+		{"340282366920938463463374607431768211455", "3.40282366920938463463374607431768211455e+38"},
+		{"NaN", "NaN"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("TestDecimalStringSci(%s)", tc.i), func(t *testing.T) {
+			d := dec128.FromString(tc.i)
+			if d.StringSci() != tc.s {
+				t.Errorf("expected %s, got %s", tc.s, d.StringSci())
+			}
+
+			if b := d.AppendStringSci([]byte("x=")); string(b) != "x="+tc.s {
+				t.Errorf("expected x=%s, got %s", tc.s, b)
+			}
+
+			if !d.IsNaN() && !dec128.FromString(d.StringSci()).Equal(d) {
+				t.Errorf("expected %s to parse back to %s", d.StringSci(), d)
+			}
+		})
+	}
+}
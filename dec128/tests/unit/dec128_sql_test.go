@@ -0,0 +1,139 @@
+package unit
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/dec128/errors"
+)
+
+var _ sql.Scanner = (*dec128.Dec128)(nil)
+var _ sql.Scanner = (*dec128.NullDec128)(nil)
+var _ driver.Valuer = dec128.Dec128{}
+var _ driver.Valuer = dec128.NullDec128{}
+
+func TestDecimalScan(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		src any
+		r   string
+		e   bool
+	}
+
+	testCases := [...]testCase{
+		{[]byte("1234.5678"), "1234.5678", false},
+		{[]byte("-0.01"), "-0.01", false},
+		{"99999999999999999999.99", "99999999999999999999.99", false},
+		{int(-7), "-7", false},
+		{int32(42), "42", false},
+		{int64(1) << 62, "4611686018427387904", false},
+		{uint64(18446744073709551615), "18446744073709551615", false},
+		{float32(0.1), "0.1", false},
+		{float64(12.345), "12.345", false},
+		{float64(1e-25), "", true},
+		{float64(1e40), "", true},
+		{[]byte("1.12345678901234567890"), "", true},
+		{"abc", "", true},
+		{nil, "", true},
+		{true, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("TestDecimalScan(%T %v)", tc.src, tc.src), func(t *testing.T) {
+			d := dec128.FromInt(-1)
+			err := d.Scan(tc.src)
+			if tc.e {
+				if err == nil {
+					t.Errorf("expected error, got %s", d)
+				}
+				if !d.Equal(dec128.FromInt(-1)) {
+					t.Errorf("expected the decimal to be left untouched, got %s", d)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if d.String() != tc.r {
+				t.Errorf("expected %s, got %s", tc.r, d)
+			}
+		})
+	}
+}
+
+func TestDecimalScanErrorMessage(t *testing.T) {
+	var d dec128.Dec128
+	err := d.Scan([]byte("abc"))
+	if err == nil || !strings.HasPrefix(err.Error(), "can't scan abc to Dec128") {
+		t.Errorf("expected the bytes to be printed as text, got %v", err)
+	}
+}
+
+func TestDecimalValue(t *testing.T) {
+	v, err := dec128.FromString("-12.50").Value()
+	if err != nil || v != "-12.5" {
+		t.Errorf("expected -12.5, got %v %v", v, err)
+	}
+
+	if _, err := dec128.NaN(errors.Overflow).Value(); err == nil {
+		t.Error("expected error storing NaN")
+	}
+}
+
+func TestNullDecimal(t *testing.T) {
+	var n dec128.NullDec128
+
+	if err := n.Scan([]byte("10.5")); err != nil {
+		t.Fatal(err)
+	}
+	if !n.Valid || n.Dec128.String() != "10.5" {
+		t.Errorf("expected valid 10.5, got %v", n)
+	}
+
+	if err := n.Scan(nil); err != nil {
+		t.Fatal(err)
+	}
+	if n.Valid || n.String() != "NULL" {
+		t.Errorf("expected NULL, got %v", n)
+	}
+
+	if v, err := n.Value(); v != nil || err != nil {
+		t.Errorf("expected nil value, got %v %v", v, err)
+	}
+
+	if v, err := dec128.NewNullDec128(dec128.FromString("3.25")).Value(); v != "3.25" || err != nil {
+		t.Errorf("expected 3.25, got %v %v", v, err)
+	}
+
+	type testStruct struct {
+		A dec128.NullDec128 `json:"a"`
+		B dec128.NullDec128 `json:"b"`
+	}
+
+	in := testStruct{A: dec128.NewNullDec128(dec128.FromString("-1.5"))}
+	s, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(s) != `{"a":"-1.5","b":null}` {
+		t.Errorf("unexpected json %s", s)
+	}
+
+	var out testStruct
+	if err := json.Unmarshal(s, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !out.A.Valid || !out.A.Dec128.Equal(in.A.Dec128) || out.B.Valid {
+		t.Errorf("expected %v, got %v", in, out)
+	}
+
+	if err := json.Unmarshal([]byte(`{"a":"x"}`), &out); err == nil {
+		t.Error("expected error unmarshalling an invalid decimal")
+	}
+}
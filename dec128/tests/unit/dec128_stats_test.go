@@ -0,0 +1,137 @@
+package unit
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+)
+
+func decimals(ss ...string) []dec128.Dec128 {
+	ds := make([]dec128.Dec128, len(ss))
+	for i, s := range ss {
+		ds[i] = dec128.FromString(s)
+	}
+	return ds
+}
+
+func TestDecimalStats(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		name string
+		f    func() dec128.Dec128
+		r    string
+		e    string
+	}
+
+	values := decimals("10.5", "2", "7.25", "4", "1")
+	half := dec128.WithPrecision(2, dec128.RoundingHalfEven)
+
+	testCases := [...]testCase{
+		{"SumOf", func() dec128.Dec128 { return dec128.SumOf(values) }, "24.75", ""},
+		{"SumOf empty", func() dec128.Dec128 { return dec128.SumOf(nil) }, "0", ""},
+		{"SumOf rounded", func() dec128.Dec128 { return dec128.SumOf(values, dec128.WithPrecision(1, dec128.RoundingHalfEven)) }, "24.8", ""},
+		{"SumOf intermediate overflow", func() dec128.Dec128 {
+			return dec128.SumOf(decimals("340282366920938463463374607431768211455", "1", "-340282366920938463463374607431768211455"))
+		}, "1", ""},
+		{"SumOf overflow", func() dec128.Dec128 {
+			return dec128.SumOf(decimals("340282366920938463463374607431768211455", "1"))
+		}, "", "overflow"},
+		{"SumOf NaN", func() dec128.Dec128 { return dec128.SumOf(decimals("1", "x", "1/0")) }, "", "invalid format"},
+		{"Mean", func() dec128.Dec128 { return dec128.Mean(values) }, "4.95", ""},
+		{"Mean repeating", func() dec128.Dec128 { return dec128.Mean(decimals("1", "1", "0")) }, "0.6666666666666666667", ""},
+		{"Mean repeating down", func() dec128.Dec128 {
+			return dec128.Mean(decimals("1", "1", "0"), dec128.WithPrecision(4, dec128.RoundingDown))
+		}, "0.6666", ""},
+		{"Mean tie", func() dec128.Dec128 { return dec128.Mean(decimals("0.01", "0.04"), half) }, "0.02", ""},
+		{"Mean empty", func() dec128.Dec128 { return dec128.Mean(nil) }, "", "division by zero"},
+		{"Avg", func() dec128.Dec128 { return dec128.Avg(dec128.One, dec128.One, dec128.Zero) }, "0.6666666666666666667", ""},
+		{"WeightedAvg", func() dec128.Dec128 {
+			return dec128.WeightedAvg(decimals("100", "200", "400"), decimals("1", "2", "1"))
+		}, "225", ""},
+		{"WeightedAvg rounded", func() dec128.Dec128 {
+			return dec128.WeightedAvg(decimals("10", "20"), decimals("1", "2"), half)
+		}, "16.67", ""},
+		{"WeightedAvg lengths", func() dec128.Dec128 { return dec128.WeightedAvg(decimals("1"), nil) }, "", "invalid format"},
+		{"WeightedAvg negative", func() dec128.Dec128 {
+			return dec128.WeightedAvg(decimals("1", "2"), decimals("1", "-1"))
+		}, "", "negative value in unsigned operation"},
+		{"WeightedAvg zero", func() dec128.Dec128 {
+			return dec128.WeightedAvg(decimals("1", "2"), decimals("0", "0"))
+		}, "", "division by zero"},
+		{"Median odd", func() dec128.Dec128 { return dec128.Median(values) }, "4", ""},
+		{"Median even", func() dec128.Dec128 { return dec128.Median(decimals("4", "1", "3", "2")) }, "2.5", ""},
+		{"Median single", func() dec128.Dec128 { return dec128.Median(decimals("-3.5")) }, "-3.5", ""},
+		{"Percentile 0", func() dec128.Dec128 { return dec128.Percentile(values, dec128.Zero) }, "1", ""},
+		{"Percentile 100", func() dec128.Dec128 { return dec128.Percentile(values, dec128.Hundred) }, "10.5", ""},
+		{"Percentile 90", func() dec128.Dec128 { return dec128.Percentile(decimals("1", "2", "3", "4"), dec128.FromInt(90)) }, "3.7", ""},
+		{"Percentile 33.3", func() dec128.Dec128 {
+			return dec128.Percentile(values, dec128.FromString("33.3"), half)
+		}, "2.66", ""},
+		{"Percentile out of range", func() dec128.Dec128 { return dec128.Percentile(values, dec128.FromInt(101)) }, "", "argument out of the function domain"},
+		{"Percentile empty", func() dec128.Dec128 { return dec128.Percentile(nil, dec128.One) }, "", "division by zero"},
+		{"Variance", func() dec128.Dec128 { return dec128.Variance(decimals("2", "4", "4", "4", "5", "5", "7", "9")) }, "4", ""},
+		{"SampleVariance", func() dec128.Dec128 {
+			return dec128.SampleVariance(decimals("2", "4", "4", "4", "5", "5", "7", "9"))
+		}, "4.5714285714285714286", ""},
+		{"StdDev", func() dec128.Dec128 { return dec128.StdDev(decimals("2", "4", "4", "4", "5", "5", "7", "9")) }, "2", ""},
+		{"SampleStdDev", func() dec128.Dec128 {
+			return dec128.SampleStdDev(decimals("2", "4", "4", "4", "5", "5", "7", "9"))
+		}, "2.1380899352993950775", ""},
+		{"SampleStdDev up", func() dec128.Dec128 {
+			return dec128.SampleStdDev(decimals("2", "4", "4", "4", "5", "5", "7", "9"), dec128.WithPrecision(3, dec128.RoundingUp))
+		}, "2.139", ""},
+		{"StdDev half", func() dec128.Dec128 {
+			return dec128.StdDev(decimals("0", "2.5"), dec128.WithPrecision(0, dec128.RoundingHalfTowardZero))
+		}, "1", ""},
+		{"SampleVariance single", func() dec128.Dec128 { return dec128.SampleVariance(decimals("1")) }, "", "division by zero"},
+		{"Variance NaN", func() dec128.Dec128 { return dec128.Variance([]dec128.Dec128{dec128.One, dec128.NaN(3)}) }, "", "overflow"},
+		{"Mean bad precision", func() dec128.Dec128 {
+			return dec128.Mean(values, dec128.WithPrecision(20, dec128.RoundingDown))
+		}, "", "precision out of range"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("TestDecimalStats(%s)", tc.name), func(t *testing.T) {
+			d := tc.f()
+			if tc.e != "" {
+				if !d.IsNaN() || d.ErrorDetails().Error() != tc.e {
+					t.Errorf("expected error %s, got %s", tc.e, d.String())
+				}
+				return
+			}
+			if d.String() != tc.r {
+				t.Errorf("expected %s, got %s", tc.r, d.String())
+			}
+		})
+	}
+}
+
+func TestDecimalCumSum(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	sums := dec128.CumSum(decimals("1.25", "2", "-0.5", "x", "3"))
+	expected := [...]string{"1.25", "3.25", "2.75", "NaN", "NaN"}
+
+	if len(sums) != len(expected) {
+		t.Fatalf("expected %d sums, got %d", len(expected), len(sums))
+	}
+
+	for i, s := range sums {
+		if s.String() != expected[i] {
+			t.Errorf("sum %d: expected %s, got %s", i, expected[i], s.String())
+		}
+	}
+
+	if sums[4].ErrorDetails().Error() != "invalid format" {
+		t.Errorf("expected the NaN to propagate, got %v", sums[4].ErrorDetails())
+	}
+
+	sums = dec128.CumSum(decimals("0.125", "0.125", "0.125"), dec128.WithPrecision(2, dec128.RoundingHalfEven))
+	for i, want := range [...]string{"0.12", "0.25", "0.38"} {
+		if sums[i].String() != want {
+			t.Errorf("rounded sum %d: expected %s, got %s", i, want, sums[i].String())
+		}
+	}
+}
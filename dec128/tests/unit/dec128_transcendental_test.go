@@ -0,0 +1,167 @@
+package unit
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+)
+
+// bigRef rounds the reference value ref, given with more digits than needed, to prec digits using math/big.
+func bigRef(t *testing.T, ref string, prec int) string {
+	t.Helper()
+
+	f, ok := new(big.Float).SetPrec(256).SetString(ref)
+	if !ok {
+		t.Fatalf("invalid reference value %s", ref)
+	}
+
+	return dec128.FromString(f.Text('f', prec)).String()
+}
+
+func TestDecimalExp(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		a   string
+		ref string
+	}
+
+	testCases := [...]testCase{
+		{"0", "1"},
+		{"1", "2.7182818284590452353602874713526624977572470936"},
+		{"-1", "0.3678794411714423215955237701614608674458111310"},
+		{"0.5", "1.6487212707001281468486507878141635716537761007"},
+		{"2.5", "12.182493960703473438070175951167966183182767790"},
+		{"10", "22026.465794806716516957900645284244366353512618"},
+		{"-10", "0.0000453999297624848515355915155605506102379180"},
+		{"0.0001", "1.0001000050001666708334166680555753970734154541"},
+		{"42.123456789", "1967817607279494810.1563065677581361188360829917"},
+		{"-45", "0.0000000000000000000286251858054939364447012162"},
+		{"-60", "0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("TestDecimalExp(%s)", tc.a), func(t *testing.T) {
+			want := bigRef(t, tc.ref, 19)
+			d := dec128.FromString(tc.a).Exp()
+			if d.String() != want {
+				t.Errorf("expected %s, got %s", want, d.String())
+			}
+		})
+	}
+}
+
+func TestDecimalExpPrecision(t *testing.T) {
+	dec128.SetDefaultPrecision(6)
+
+	if d := dec128.One.Exp(); d.String() != "2.718282" {
+		t.Errorf("expected 2.718282, got %s", d.String())
+	}
+
+	// e^88 has 39 digits, so no decimals fit
+	dec128.SetDefaultPrecision(19)
+	want := bigRef(t, "165163625499400185552832979626485876706.96288420", 0)
+	if d := dec128.FromInt(88).Exp(); d.String() != want {
+		t.Errorf("expected %s, got %s", want, d.String())
+	}
+
+	if d := dec128.FromString("89.5").Exp(); !d.IsNaN() || d.ErrorDetails().Error() != "overflow" {
+		t.Errorf("expected overflow, got %s", d.String())
+	}
+}
+
+func TestDecimalLn(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		a     string
+		ln    string
+		log10 string
+	}
+
+	testCases := [...]testCase{
+		{"1", "0", "0"},
+		{"2", "0.6931471805599453094172321214581765680755001343", "0.3010299956639811952137388947244930267681898814"},
+		{"10", "2.3025850929940456840179914546843642076011014886", "1"},
+		{"1000", "6.9077552789821370520539743640530926228033044658", "3"},
+		{"0.5", "-0.693147180559945309417232121458176568075500134", "-0.301029995663981195213738894724493026768189881"},
+		{"3.14159", "1.1447290411851783812164125804361594587905928083", "0.4971495058611232685786388514963668654522077362"},
+		{"0.0000000000000000001", "-43.74911676688686799634183763900291994442092828", "-19"},
+		{"123456789.123456789", "18.631401767168018032693933348296537542797015174", "8.0915149776035649292044382099038150907986242839"},
+		{"0.9999999999", "-0.000000000100000000005000000000333333333358333", "-0.000000000043429448192496655174773915857228094"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("TestDecimalLn(%s)", tc.a), func(t *testing.T) {
+			a := dec128.FromString(tc.a)
+
+			want := bigRef(t, tc.ln, 19)
+			if d := a.Ln(); d.String() != want {
+				t.Errorf("ln: expected %s, got %s", want, d.String())
+			}
+
+			want = bigRef(t, tc.log10, 19)
+			if d := a.Log10(); d.String() != want {
+				t.Errorf("log10: expected %s, got %s", want, d.String())
+			}
+		})
+	}
+
+	for _, a := range [...]string{"0", "-1"} {
+		t.Run(fmt.Sprintf("TestDecimalLn(%s)", a), func(t *testing.T) {
+			for _, d := range [...]dec128.Dec128{dec128.FromString(a).Ln(), dec128.FromString(a).Log10()} {
+				if !d.IsNaN() || d.ErrorDetails().Error() != "argument out of the function domain" {
+					t.Errorf("expected out of domain error, got %s", d.String())
+				}
+			}
+		})
+	}
+}
+
+func TestDecimalPow(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		a   string
+		b   string
+		ref string
+		e   string
+	}
+
+	testCases := [...]testCase{
+		{"1.05", "0.0833333333333333333", "1.0040741237836483016037866379608510705475000538", ""},
+		{"2", "0.5", "1.4142135623730950488016887242096980785696718753", ""},
+		{"4", "0.5", "2", ""},
+		{"10", "-2.5", "0.0031622776601683793319988935444327185337195551", ""},
+		{"1.0125", "12", "1.1607545177229987146472703898325562477111816406", ""},
+		{"0.95", "-3.75", "1.2120944988469195983617464097919624913293862623", ""},
+		{"-2", "3", "-8", ""},
+		{"-2", "-2", "0.25", ""},
+		{"-2", "2.00", "4", ""},
+		{"123", "0", "1", ""},
+		{"0", "2.5", "0", ""},
+		{"0", "-1", "", "division by zero"},
+		{"-2", "0.5", "", "argument out of the function domain"},
+		{"10", "39", "", "overflow"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("TestDecimalPow(%s, %s)", tc.a, tc.b), func(t *testing.T) {
+			d := dec128.FromString(tc.a).Pow(dec128.FromString(tc.b))
+
+			if tc.e != "" {
+				if !d.IsNaN() || d.ErrorDetails().Error() != tc.e {
+					t.Errorf("expected %s, got %s", tc.e, d.String())
+				}
+				return
+			}
+
+			want := bigRef(t, tc.ref, 19)
+			if d.String() != want {
+				t.Errorf("expected %s, got %s", want, d.String())
+			}
+		})
+	}
+}
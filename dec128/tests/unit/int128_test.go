@@ -0,0 +1,277 @@
+package unit
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128/errors"
+	"github.com/profe-ajedrez/badassitron/dec128/uint128"
+)
+
+func TestInt128ConvString(t *testing.T) {
+	testCases := [...]string{
+		"0",
+		"1",
+		"-1",
+		"1234567890",
+		"-1234567890",
+		"9223372036854775807",
+		"-9223372036854775808",
+		"18446744073709551616",
+		"-18446744073709551616",
+		"-12345678901234567890123456789",
+		uint128.MaxInt128Str,
+		uint128.MinInt128Str,
+	}
+
+	for _, s := range testCases {
+		t.Run(s, func(t *testing.T) {
+			i, err := uint128.Int128FromString(s)
+			if err != errors.None {
+				t.Fatalf("error parsing %s: %v", s, err.Value())
+			}
+
+			if i.String() != s {
+				t.Errorf("expected %s, got %s", s, i.String())
+			}
+
+			b, _ := new(big.Int).SetString(s, 10)
+			if i.BigInt().Cmp(b) != 0 {
+				t.Errorf("expected big.Int %s, got %s", s, i.BigInt())
+			}
+
+			j, err := uint128.Int128FromBigInt(b)
+			if err != errors.None || !j.Equal(i) {
+				t.Errorf("expected %s from big.Int, got %s (%v)", s, j, err.Value())
+			}
+		})
+	}
+
+	if i, err := uint128.Int128FromString("+42"); err != errors.None || i.String() != "42" {
+		t.Errorf("expected 42, got %s (%v)", i, err.Value())
+	}
+
+	if i, _ := uint128.Int128FromString(uint128.MaxInt128Str); !i.Equal(uint128.MaxInt128) {
+		t.Errorf("expected MaxInt128, got %s", i)
+	}
+
+	if i, _ := uint128.Int128FromString(uint128.MinInt128Str); !i.Equal(uint128.MinInt128) {
+		t.Errorf("expected MinInt128, got %s", i)
+	}
+}
+
+func TestInt128ConvStringErrors(t *testing.T) {
+	type testCase struct {
+		s   string
+		err errors.Error
+	}
+
+	testCases := [...]testCase{
+		{"-", errors.InvalidFormat},
+		{"+", errors.InvalidFormat},
+		{"1a", errors.InvalidFormat},
+		{"--1", errors.InvalidFormat},
+		{"170141183460469231731687303715884105728", errors.Overflow},
+		{"-170141183460469231731687303715884105729", errors.Underflow},
+		{"340282366920938463463374607431768211456", errors.Overflow},
+		{"-340282366920938463463374607431768211456", errors.Underflow},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.s, func(t *testing.T) {
+			_, err := uint128.Int128FromString(tc.s)
+			if err != tc.err {
+				t.Errorf("expected error %v, got %v", tc.err.Value(), err.Value())
+			}
+		})
+	}
+}
+
+func TestInt128ConvInt64(t *testing.T) {
+	testCases := [...]int64{0, 1, -1, 1234567890, -1234567890, 9223372036854775807, -9223372036854775808}
+	for _, i := range testCases {
+		u := uint128.Int128FromInt64(i)
+		j, err := u.Int64()
+		if err != errors.None {
+			t.Errorf("error converting int128 to int64: %v", err.Value())
+		}
+		if i != j {
+			t.Errorf("expected %v, got %v", i, j)
+		}
+	}
+
+	large, _ := uint128.Int128FromInt64(9223372036854775807).Add64(1)
+	if _, err := large.Int64(); err != errors.Overflow {
+		t.Errorf("expected overflow, got %v", err.Value())
+	}
+
+	small, _ := uint128.Int128FromInt64(-9223372036854775808).Sub64(1)
+	if _, err := small.Int64(); err != errors.Underflow {
+		t.Errorf("expected underflow, got %v", err.Value())
+	}
+}
+
+func TestInt128ConvUint128(t *testing.T) {
+	i, err := uint128.Int128FromUint128(uint128.FromUint64(42))
+	if err != errors.None || i.String() != "42" {
+		t.Errorf("expected 42, got %s (%v)", i, err.Value())
+	}
+
+	if _, err := uint128.Int128FromUint128(uint128.Max); err != errors.Overflow {
+		t.Errorf("expected overflow, got %v", err.Value())
+	}
+
+	if _, err := uint128.Int128FromInt64(-1).Uint128(); err != errors.Negative {
+		t.Errorf("expected negative, got %v", err.Value())
+	}
+
+	if u := uint128.MinInt128.AbsUint128(); u.String() != uint128.MinInt128Str[1:] {
+		t.Errorf("expected %s, got %s", uint128.MinInt128Str[1:], u)
+	}
+}
+
+func TestInt128Arithmetic(t *testing.T) {
+	type testCase struct {
+		a, b           string
+		add, sub, mul  string
+		quo, rem       string
+		addErr, subErr errors.Error
+		mulErr, quoErr errors.Error
+	}
+
+	testCases := [...]testCase{
+		{a: "7", b: "2", add: "9", sub: "5", mul: "14", quo: "3", rem: "1"},
+		{a: "-7", b: "2", add: "-5", sub: "-9", mul: "-14", quo: "-3", rem: "-1"},
+		{a: "7", b: "-2", add: "5", sub: "9", mul: "-14", quo: "-3", rem: "1"},
+		{a: "-7", b: "-2", add: "-9", sub: "-5", mul: "14", quo: "3", rem: "-1"},
+		{a: "0", b: "-5", add: "-5", sub: "5", mul: "0", quo: "0", rem: "0"},
+		{
+			a: "-18446744073709551616", b: "18446744073709551616",
+			add: "0", sub: "-36893488147419103232", mul: "-340282366920938463463374607431768211456",
+			quo: "-1", rem: "0", mulErr: errors.Underflow,
+		},
+		{
+			a: uint128.MaxInt128Str, b: "1",
+			sub: "170141183460469231731687303715884105726", mul: uint128.MaxInt128Str,
+			quo: uint128.MaxInt128Str, rem: "0", addErr: errors.Overflow,
+		},
+		{
+			a: uint128.MinInt128Str, b: "1",
+			add: "-170141183460469231731687303715884105727", mul: uint128.MinInt128Str,
+			quo: uint128.MinInt128Str, rem: "0", subErr: errors.Underflow,
+		},
+		{
+			a: uint128.MinInt128Str, b: "-1",
+			sub: "-170141183460469231731687303715884105727", addErr: errors.Underflow,
+			mulErr: errors.Overflow, quoErr: errors.Overflow,
+		},
+		{a: "5", b: "0", add: "5", sub: "5", mul: "0", quoErr: errors.DivisionByZero},
+	}
+
+	check := func(t *testing.T, op string, got uint128.Int128, err errors.Error, want string, wantErr errors.Error) {
+		t.Helper()
+		if err != wantErr {
+			t.Errorf("%s: expected error %v, got %v", op, wantErr.Value(), err.Value())
+			return
+		}
+		if err == errors.None && got.String() != want {
+			t.Errorf("%s: expected %s, got %s", op, want, got)
+		}
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			a, _ := uint128.Int128FromString(tc.a)
+			b, _ := uint128.Int128FromString(tc.b)
+
+			r, err := a.Add(b)
+			check(t, "add", r, err, tc.add, tc.addErr)
+
+			r, err = a.Sub(b)
+			check(t, "sub", r, err, tc.sub, tc.subErr)
+
+			r, err = a.Mul(b)
+			check(t, "mul", r, err, tc.mul, tc.mulErr)
+
+			q, m, err := a.QuoRem(b)
+			check(t, "quo", q, err, tc.quo, tc.quoErr)
+			check(t, "rem", m, err, tc.rem, tc.quoErr)
+		})
+	}
+}
+
+func TestInt128Compare(t *testing.T) {
+	values := [...]uint128.Int128{
+		uint128.MinInt128,
+		uint128.Int128FromInt64(-9223372036854775808),
+		uint128.Int128FromInt64(-1),
+		{},
+		uint128.Int128FromInt64(1),
+		uint128.Int128FromInt64(9223372036854775807),
+		uint128.MaxInt128,
+	}
+
+	for i := range values {
+		for j := range values {
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+
+			if got := values[i].Compare(values[j]); got != want {
+				t.Errorf("compare %s %s: expected %d, got %d", values[i], values[j], want, got)
+			}
+		}
+	}
+
+	if _, err := uint128.MinInt128.Neg(); err != errors.Overflow {
+		t.Errorf("expected overflow negating MinInt128, got %v", err.Value())
+	}
+
+	if r, err := uint128.MaxInt128.Neg(); err != errors.None || r.String() != "-"+uint128.MaxInt128Str {
+		t.Errorf("expected -MaxInt128, got %s (%v)", r, err.Value())
+	}
+}
+
+func TestInt128Marshal(t *testing.T) {
+	type payload struct {
+		V uint128.Int128 `json:"v"`
+	}
+
+	for _, s := range [...]string{"0", "-1", uint128.MinInt128Str, uint128.MaxInt128Str} {
+		t.Run(s, func(t *testing.T) {
+			i, _ := uint128.Int128FromString(s)
+
+			b, err := json.Marshal(payload{i})
+			if err != nil {
+				t.Fatalf("error marshaling: %v", err)
+			}
+
+			if string(b) != `{"v":"`+s+`"}` {
+				t.Errorf("expected %s, got %s", s, b)
+			}
+
+			var p payload
+			if err := json.Unmarshal(b, &p); err != nil {
+				t.Fatalf("error unmarshaling: %v", err)
+			}
+
+			if !p.V.Equal(i) {
+				t.Errorf("expected %s, got %s", i, p.V)
+			}
+
+			a, _ := i.AppendText([]byte("x="))
+			if string(a) != "x="+s {
+				t.Errorf("expected x=%s, got %s", s, a)
+			}
+		})
+	}
+
+	var p payload
+	if err := json.Unmarshal([]byte(`{"v":"1e3"}`), &p); err == nil {
+		t.Errorf("expected error unmarshaling invalid value")
+	}
+}
@@ -28,7 +28,8 @@ func Sum(a Dec128, b ...Dec128) Dec128 {
 	return a
 }
 
-// Avg returns the average of the Dec128 values in the input list.
+// Avg returns the average of the Dec128 values in the input list, rounded half away from zero at the default precision.
+// Use Mean to choose the precision and rounding.
 func Avg(a Dec128, b ...Dec128) Dec128 {
-	return Sum(a, b...).DivInt(len(b) + 1)
+	return Mean(append([]Dec128{a}, b...))
 }
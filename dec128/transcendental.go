@@ -0,0 +1,265 @@
+package dec128
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/profe-ajedrez/badassitron/dec128/errors"
+	"github.com/profe-ajedrez/badassitron/dec128/uint128"
+)
+
+// workScale is the number of digits after the decimal point kept by the fixed-point arithmetic behind Exp, Ln, Log10 and Pow.
+// It leaves enough guard digits over MaxPrecision for the results to be rounded correctly even when they use all the 38 digits of the coefficient.
+const workScale = 64
+
+var (
+	workOne      = new(big.Int).Exp(big.NewInt(10), big.NewInt(workScale), nil)
+	workConsts   sync.Once
+	workLn2      *big.Int
+	workLn10     *big.Int
+	workExpLimit *big.Int // above it Exp overflows, e^89 > 2^128
+	workExpFloor *big.Int // below it Exp rounds to zero at any precision, e^-50 < 10^-21
+)
+
+// Exp returns e raised to the power of the decimal, rounded half away from zero at the default precision.
+// It returns NaN with Overflow if the result does not fit in a Dec128.
+func (decimal Dec128) Exp() Dec128 {
+	if decimal.err != errors.None {
+		return decimal
+	}
+
+	if decimal.IsZero() {
+		return One
+	}
+
+	return fromWork(expWork(decimal.toWork()))
+}
+
+// Ln returns the natural logarithm of the decimal, rounded half away from zero at the default precision.
+// It returns NaN with OutOfDomain if the decimal is zero or negative.
+func (decimal Dec128) Ln() Dec128 {
+	if decimal.err != errors.None {
+		return decimal
+	}
+
+	if decimal.IsZero() || decimal.neg {
+		return NaN(errors.OutOfDomain)
+	}
+
+	return fromWork(lnWork(decimal.toWork()))
+}
+
+// Log10 returns the base 10 logarithm of the decimal, rounded half away from zero at the default precision.
+// It returns NaN with OutOfDomain if the decimal is zero or negative.
+func (decimal Dec128) Log10() Dec128 {
+	if decimal.err != errors.None {
+		return decimal
+	}
+
+	if decimal.IsZero() || decimal.neg {
+		return NaN(errors.OutOfDomain)
+	}
+
+	l := lnWork(decimal.toWork())
+	l.Mul(l, workOne)
+
+	return fromWork(quoWork(l, workLn10))
+}
+
+// Pow returns the decimal raised to the power of other, rounded half away from zero at the default precision.
+// Unlike PowInt, the exponent can have decimals, e.g. (1 + r)^(n/12).
+//
+// A negative decimal can only be raised to an integer power, otherwise NaN with OutOfDomain is returned.
+// Zero raised to a negative power returns NaN with DivisionByZero.
+// It returns NaN with Overflow if the result does not fit in a Dec128.
+func (decimal Dec128) Pow(other Dec128) Dec128 {
+	if decimal.err != errors.None {
+		return decimal
+	}
+
+	if other.err != errors.None {
+		return other
+	}
+
+	if other.IsZero() {
+		return One
+	}
+
+	if decimal.IsZero() {
+		if other.neg {
+			return NaN(errors.DivisionByZero)
+		}
+		return Zero
+	}
+
+	neg := false
+	if decimal.neg {
+		y := other.Canonical()
+		if y.exp != 0 {
+			return NaN(errors.OutOfDomain)
+		}
+		neg = y.coef.Lo&1 == 1
+	}
+
+	l := lnWork(decimal.Abs().toWork())
+	l.Mul(l, other.toWork())
+	l = quoWork(l, workOne)
+
+	r := expWork(l)
+	if r != nil && neg {
+		r.Neg(r)
+	}
+
+	return fromWork(r)
+}
+
+// toWork returns the decimal as a fixed-point big.Int with workScale digits after the decimal point.
+func (decimal Dec128) toWork() *big.Int {
+	x := decimal.coef.BigInt()
+	x.Mul(x, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(workScale-decimal.exp)), nil))
+	if decimal.neg {
+		x.Neg(x)
+	}
+	return x
+}
+
+// fromWork rounds the fixed-point x half away from zero to the default precision.
+// If the result does not fit, it drops as many digits after the decimal point as needed.
+func fromWork(x *big.Int) Dec128 {
+	if x == nil {
+		return NaN(errors.Overflow)
+	}
+
+	neg := x.Sign() < 0
+	abs := new(big.Int).Abs(x)
+
+	for prec := int(defaultPrecision); prec >= 0; prec-- {
+		d := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(workScale-prec)), nil)
+		q, r := new(big.Int).QuoRem(abs, d, new(big.Int))
+		if r.Lsh(r, 1).Cmp(d) >= 0 {
+			q.Add(q, big.NewInt(1))
+		}
+
+		coef, err := uint128.FromBigInt(q)
+		if err != errors.None {
+			continue
+		}
+
+		if coef.IsZero() {
+			return Zero
+		}
+
+		return Dec128{coef: coef, exp: uint8(prec), neg: neg}
+	}
+
+	return NaN(errors.Overflow)
+}
+
+// quoWork returns x / y rounded half away from zero.
+func quoWork(x, y *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(x, y, new(big.Int))
+	if r.Abs(r).Lsh(r, 1).CmpAbs(y) >= 0 {
+		if x.Sign() == y.Sign() {
+			q.Add(q, big.NewInt(1))
+		} else {
+			q.Sub(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// expWork returns e^x for the fixed-point x, or nil if the result is too large for a Dec128.
+func expWork(x *big.Int) *big.Int {
+	initWorkConsts()
+
+	if x.Cmp(workExpLimit) > 0 {
+		return nil
+	}
+
+	if x.Cmp(workExpFloor) < 0 {
+		return new(big.Int)
+	}
+
+	// e^x = 2^k * e^r, with |r| <= ln(2)/2
+	k := quoWork(x, workLn2)
+	r := new(big.Int).Mul(k, workLn2)
+	r.Sub(x, r)
+
+	// Taylor series, e^r = 1 + r + r^2/2! + r^3/3! + ...
+	sum := new(big.Int).Set(workOne)
+	term := new(big.Int).Set(workOne)
+	for i := int64(1); ; i++ {
+		term.Mul(term, r)
+		term = quoWork(term, new(big.Int).Mul(workOne, big.NewInt(i)))
+		if term.Sign() == 0 {
+			break
+		}
+		sum.Add(sum, term)
+	}
+
+	shift := k.Int64()
+	if shift >= 0 {
+		return sum.Lsh(sum, uint(shift))
+	}
+
+	half := new(big.Int).Lsh(big.NewInt(1), uint(-shift-1))
+	return sum.Add(sum, half).Rsh(sum, uint(-shift))
+}
+
+// lnWork returns ln(x) for the positive fixed-point x.
+func lnWork(x *big.Int) *big.Int {
+	initWorkConsts()
+
+	// ln(x) = k * ln(2) + ln(z), with z = x / 2^k in [0.5, 2)
+	k := x.BitLen() - workOne.BitLen()
+	z := new(big.Int).Set(x)
+	if k > 0 {
+		half := new(big.Int).Lsh(big.NewInt(1), uint(k-1))
+		z.Add(z, half).Rsh(z, uint(k))
+	} else if k < 0 {
+		z.Lsh(z, uint(-k))
+	}
+
+	// ln(z) = 2 * atanh((z - 1) / (z + 1))
+	num := new(big.Int).Sub(z, workOne)
+	den := new(big.Int).Add(z, workOne)
+	l := atanhWork(quoWork(num.Mul(num, workOne), den))
+	l.Lsh(l, 1)
+
+	return l.Add(l, new(big.Int).Mul(big.NewInt(int64(k)), workLn2))
+}
+
+// atanhWork returns atanh(u) for the fixed-point u, |u| <= 1/3.
+func atanhWork(u *big.Int) *big.Int {
+	// atanh(u) = u + u^3/3 + u^5/5 + ...
+	u2 := quoWork(new(big.Int).Mul(u, u), workOne)
+	sum := new(big.Int).Set(u)
+	pow := new(big.Int).Set(u)
+	for n := int64(3); ; n += 2 {
+		pow = quoWork(pow.Mul(pow, u2), workOne)
+		term := quoWork(pow, big.NewInt(n))
+		if term.Sign() == 0 {
+			break
+		}
+		sum.Add(sum, term)
+	}
+	return sum
+}
+
+func initWorkConsts() {
+	workConsts.Do(func() {
+		// both come straight from the series, as lnWork needs them
+		// ln(2) = 2 * atanh(1/3)
+		third := quoWork(workOne, big.NewInt(3))
+		workLn2 = atanhWork(third)
+		workLn2.Lsh(workLn2, 1)
+
+		// ln(10) = 3 * ln(2) + ln(1.25), ln(1.25) = 2 * atanh(1/9)
+		workLn10 = atanhWork(quoWork(workOne, big.NewInt(9)))
+		workLn10.Lsh(workLn10, 1)
+		workLn10.Add(workLn10, new(big.Int).Mul(workLn2, big.NewInt(3)))
+
+		workExpLimit = new(big.Int).Mul(workOne, big.NewInt(89))
+		workExpFloor = new(big.Int).Mul(workOne, big.NewInt(-50))
+	})
+}
@@ -75,3 +75,31 @@ func FromString[S string | []byte](s S) (Uint128, errors.Error) {
 
 	return u, errors.None
 }
+
+// FromUvarint decodes a Uint128 written by AppendUvarint from the start of bs.
+// It returns the value and the number of bytes read.
+func FromUvarint(bs []byte) (Uint128, int, errors.Error) {
+	var u Uint128
+	var shift uint
+
+	for i, b := range bs {
+		if shift == 126 && b > 0x03 {
+			// the last group can only hold the 2 remaining bits
+			return Zero, 0, errors.Overflow
+		}
+
+		group := Uint128{Lo: uint64(b & 0x7f)}
+		if shift > 0 {
+			group = group.Lsh(shift)
+		}
+		u = u.Or(group)
+
+		if b < 0x80 {
+			return u, i + 1, errors.None
+		}
+
+		shift += 7
+	}
+
+	return Zero, 0, errors.NotEnoughBytes
+}
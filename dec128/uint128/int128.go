@@ -0,0 +1,233 @@
+package uint128
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/profe-ajedrez/badassitron/dec128/errors"
+)
+
+const (
+	// MaxInt128Str is the string representation of the maximum Int128 value.
+	MaxInt128Str = "170141183460469231731687303715884105727"
+
+	// MinInt128Str is the string representation of the minimum Int128 value.
+	MinInt128Str = "-170141183460469231731687303715884105728"
+)
+
+var (
+	// MaxInt128 is the maximum Int128 value, 2^127 - 1.
+	MaxInt128 = Int128{Lo: math.MaxUint64, Hi: math.MaxInt64}
+
+	// MinInt128 is the minimum Int128 value, -2^127.
+	MinInt128 = Int128{Lo: 0, Hi: 1 << 63}
+)
+
+// Int128 is a 128-bit signed integer type, stored in two's complement.
+type Int128 struct {
+	Lo uint64
+	Hi uint64
+}
+
+// IsZero returns true if the value is zero.
+func (int128 Int128) IsZero() bool {
+	return int128.Lo == 0 && int128.Hi == 0
+}
+
+// IsNegative returns true if the value is less than zero.
+func (int128 Int128) IsNegative() bool {
+	return int128.Hi>>63 == 1
+}
+
+// Sign returns -1 if the value is negative, 0 if it is zero, and 1 if it is positive.
+func (int128 Int128) Sign() int {
+	if int128.IsZero() {
+		return 0
+	}
+
+	if int128.IsNegative() {
+		return -1
+	}
+
+	return 1
+}
+
+// Equal returns true if the value is equal to the other value.
+func (int128 Int128) Equal(other Int128) bool {
+	return int128.Lo == other.Lo && int128.Hi == other.Hi
+}
+
+// Compare returns -1 if the value is less than the other value, 0 if the value is equal to the other value, and 1 if the value is greater than the other value.
+func (int128 Int128) Compare(other Int128) int {
+	if int128 == other {
+		return 0
+	}
+
+	if int64(int128.Hi) < int64(other.Hi) || (int128.Hi == other.Hi && int128.Lo < other.Lo) {
+		return -1
+	}
+
+	return 1
+}
+
+// Neg returns -int128 and an error if the result overflows, which only happens for MinInt128.
+func (int128 Int128) Neg() (Int128, errors.Error) {
+	if int128 == MinInt128 {
+		return Int128{}, errors.Overflow
+	}
+	return int128.negUnsafe(), errors.None
+}
+
+// Abs returns |int128| and an error if the result overflows, which only happens for MinInt128.
+func (int128 Int128) Abs() (Int128, errors.Error) {
+	if int128.IsNegative() {
+		return int128.Neg()
+	}
+	return int128, errors.None
+}
+
+// AbsUint128 returns |int128| as Uint128, which can hold the absolute value of MinInt128.
+func (int128 Int128) AbsUint128() Uint128 {
+	if int128.IsNegative() {
+		n := int128.negUnsafe()
+		return Uint128{Lo: n.Lo, Hi: n.Hi}
+	}
+	return Uint128{Lo: int128.Lo, Hi: int128.Hi}
+}
+
+// Add returns int128 + other and an error if the result overflows or underflows.
+func (int128 Int128) Add(other Int128) (Int128, errors.Error) {
+	lo, carry := bits.Add64(int128.Lo, other.Lo, 0)
+	hi, _ := bits.Add64(int128.Hi, other.Hi, carry)
+	r := Int128{lo, hi}
+
+	// overflow happens only when both operands have the same sign and the result has the other one
+	if int128.IsNegative() == other.IsNegative() && r.IsNegative() != int128.IsNegative() {
+		if int128.IsNegative() {
+			return Int128{}, errors.Underflow
+		}
+		return Int128{}, errors.Overflow
+	}
+
+	return r, errors.None
+}
+
+// Add64 returns int128 + other and an error if the result overflows or underflows.
+func (int128 Int128) Add64(other int64) (Int128, errors.Error) {
+	return int128.Add(Int128FromInt64(other))
+}
+
+// Sub returns int128 - other and an error if the result overflows or underflows.
+func (int128 Int128) Sub(other Int128) (Int128, errors.Error) {
+	lo, borrow := bits.Sub64(int128.Lo, other.Lo, 0)
+	hi, _ := bits.Sub64(int128.Hi, other.Hi, borrow)
+	r := Int128{lo, hi}
+
+	// overflow happens only when the operands have different signs and the result has the sign of other
+	if int128.IsNegative() != other.IsNegative() && r.IsNegative() != int128.IsNegative() {
+		if int128.IsNegative() {
+			return Int128{}, errors.Underflow
+		}
+		return Int128{}, errors.Overflow
+	}
+
+	return r, errors.None
+}
+
+// Sub64 returns int128 - other and an error if the result overflows or underflows.
+func (int128 Int128) Sub64(other int64) (Int128, errors.Error) {
+	return int128.Sub(Int128FromInt64(other))
+}
+
+// Mul returns int128 * other and an error if the result overflows or underflows.
+func (int128 Int128) Mul(other Int128) (Int128, errors.Error) {
+	neg := int128.IsNegative() != other.IsNegative()
+
+	u, err := int128.AbsUint128().Mul(other.AbsUint128())
+	if err != errors.None {
+		return Int128{}, overflowError(neg)
+	}
+
+	return fromSignedMagnitude(u, neg)
+}
+
+// Mul64 returns int128 * other and an error if the result overflows or underflows.
+func (int128 Int128) Mul64(other int64) (Int128, errors.Error) {
+	return int128.Mul(Int128FromInt64(other))
+}
+
+// Div returns int128 / other truncated toward zero, and an error if the divisor is zero or the result overflows.
+func (int128 Int128) Div(other Int128) (Int128, errors.Error) {
+	q, _, err := int128.QuoRem(other)
+	return q, err
+}
+
+// Div64 returns int128 / other truncated toward zero, and an error if the divisor is zero or the result overflows.
+func (int128 Int128) Div64(other int64) (Int128, errors.Error) {
+	return int128.Div(Int128FromInt64(other))
+}
+
+// Mod returns int128 % other, with the sign of int128, and an error if the divisor is zero.
+func (int128 Int128) Mod(other Int128) (Int128, errors.Error) {
+	_, r, err := int128.QuoRem(other)
+	return r, err
+}
+
+// Mod64 returns int128 % other, with the sign of int128, and an error if the divisor is zero.
+func (int128 Int128) Mod64(other int64) (Int128, errors.Error) {
+	return int128.Mod(Int128FromInt64(other))
+}
+
+// QuoRem returns int128 / other truncated toward zero and int128 % other, like the Go / and % operators do.
+// It returns an error if the divisor is zero, or if the quotient overflows (MinInt128 / -1).
+func (int128 Int128) QuoRem(other Int128) (Int128, Int128, errors.Error) {
+	if other.IsZero() {
+		return Int128{}, Int128{}, errors.DivisionByZero
+	}
+
+	q, r, err := int128.AbsUint128().QuoRem(other.AbsUint128())
+	if err != errors.None {
+		return Int128{}, Int128{}, err
+	}
+
+	qi, err := fromSignedMagnitude(q, int128.IsNegative() != other.IsNegative())
+	if err != errors.None {
+		return Int128{}, Int128{}, err
+	}
+
+	// |r| < |other| <= 2^127, so it always fits
+	ri, _ := fromSignedMagnitude(r, int128.IsNegative())
+
+	return qi, ri, errors.None
+}
+
+func (int128 Int128) negUnsafe() Int128 {
+	lo, borrow := bits.Sub64(0, int128.Lo, 0)
+	hi, _ := bits.Sub64(0, int128.Hi, borrow)
+	return Int128{lo, hi}
+}
+
+// fromSignedMagnitude returns the Int128 with absolute value u and sign neg, and an error if it doesn't fit.
+func fromSignedMagnitude(u Uint128, neg bool) (Int128, errors.Error) {
+	if u.Hi>>63 == 1 {
+		// only 2^127 fits, and only as a negative value
+		if neg && u.Hi == 1<<63 && u.Lo == 0 {
+			return MinInt128, errors.None
+		}
+		return Int128{}, overflowError(neg)
+	}
+
+	i := Int128{Lo: u.Lo, Hi: u.Hi}
+	if neg {
+		return i.negUnsafe(), errors.None
+	}
+
+	return i, errors.None
+}
+
+func overflowError(neg bool) errors.Error {
+	if neg {
+		return errors.Underflow
+	}
+	return errors.Overflow
+}
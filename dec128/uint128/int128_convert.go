@@ -0,0 +1,145 @@
+package uint128
+
+import (
+	"math/big"
+
+	"github.com/profe-ajedrez/badassitron/dec128/errors"
+)
+
+// Int128FromInt64 creates a new Int128 from an int64.
+func Int128FromInt64(i int64) Int128 {
+	if i < 0 {
+		return Int128{Lo: uint64(i), Hi: ^uint64(0)}
+	}
+	return Int128{Lo: uint64(i)}
+}
+
+// Int128FromUint128 creates a new Int128 from a Uint128 and an error if it doesn't fit.
+func Int128FromUint128(u Uint128) (Int128, errors.Error) {
+	return fromSignedMagnitude(u, false)
+}
+
+// Int128FromBigInt creates a new Int128 from a *big.Int and an error if it doesn't fit.
+func Int128FromBigInt(i *big.Int) (Int128, errors.Error) {
+	abs := new(big.Int).Abs(i)
+	if abs.BitLen() > 128 {
+		return Int128{}, overflowError(i.Sign() < 0)
+	}
+
+	u := Uint128{Lo: abs.Uint64(), Hi: abs.Rsh(abs, 64).Uint64()}
+	return fromSignedMagnitude(u, i.Sign() < 0)
+}
+
+// Int128FromString creates a new Int128 from a string in the format [+-][0-9]+
+func Int128FromString[S string | []byte](s S) (Int128, errors.Error) {
+	if len(s) == 0 {
+		return Int128{}, errors.None
+	}
+
+	neg := false
+	switch s[0] {
+	case '-':
+		neg = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	if len(s) == 0 {
+		return Int128{}, errors.InvalidFormat
+	}
+
+	u, err := FromString(s)
+	if err != errors.None {
+		if err == errors.Overflow {
+			return Int128{}, overflowError(neg)
+		}
+		return Int128{}, err
+	}
+
+	return fromSignedMagnitude(u, neg)
+}
+
+// Int64 returns the value as int64 if it fits, otherwise it returns an error.
+func (int128 Int128) Int64() (int64, errors.Error) {
+	if int128.IsNegative() {
+		if int128.Hi != ^uint64(0) || int128.Lo < 1<<63 {
+			return 0, errors.Underflow
+		}
+		return int64(int128.Lo), errors.None
+	}
+
+	if int128.Hi != 0 || int128.Lo >= 1<<63 {
+		return 0, errors.Overflow
+	}
+
+	return int64(int128.Lo), errors.None
+}
+
+// Uint128 returns the value as Uint128, or an error if it is negative.
+func (int128 Int128) Uint128() (Uint128, errors.Error) {
+	if int128.IsNegative() {
+		return Zero, errors.Negative
+	}
+	return Uint128{Lo: int128.Lo, Hi: int128.Hi}, errors.None
+}
+
+// BigInt returns the value as a big.Int.
+func (int128 Int128) BigInt() *big.Int {
+	i := int128.AbsUint128().BigInt()
+	if int128.IsNegative() {
+		i = i.Neg(i)
+	}
+	return i
+}
+
+// String returns the value as a string.
+func (int128 Int128) String() string {
+	if int128.IsZero() {
+		return ZeroStr
+	}
+
+	buf := [MaxStrLen + 1]byte{}
+	return string(int128.StringToBuf(buf[:]))
+}
+
+// StringToBuf writes the value as a string to the given buffer (from end to start) and returns a slice containing the string.
+// The buffer needs MaxStrLen + 1 bytes to hold any value, sign included.
+func (int128 Int128) StringToBuf(buf []byte) []byte {
+	sb := int128.AbsUint128().StringToBuf(buf)
+	if !int128.IsNegative() {
+		return sb
+	}
+
+	i := len(buf) - len(sb) - 1
+	buf[i] = '-'
+	return buf[i:]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// As a TextMarshaler, it also marshals to JSON as a string.
+func (int128 Int128) MarshalText() ([]byte, error) {
+	return []byte(int128.String()), nil
+}
+
+// AppendText implements the encoding.TextAppender interface.
+func (int128 Int128) AppendText(b []byte) ([]byte, error) {
+	if int128.IsZero() {
+		return append(b, ZeroStr...), nil
+	}
+
+	buf := [MaxStrLen + 1]byte{}
+	return append(b, int128.StringToBuf(buf[:])...), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// As a TextUnmarshaler, it also unmarshals JSON strings.
+func (int128 *Int128) UnmarshalText(b []byte) error {
+	i, err := Int128FromString(b)
+	if err != errors.None {
+		return err.Value()
+	}
+
+	*int128 = i
+	return nil
+}
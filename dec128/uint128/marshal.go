@@ -1,12 +1,29 @@
 package uint128
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/profe-ajedrez/badassitron/dec128/errors"
+)
+
+// BinaryVersion is the version of the binary encoding written by MarshalBinary and AppendBinary.
+const BinaryVersion = 1
 
 // MarshalText implements the encoding.TextMarshaler interface.
 func (uint128 Uint128) MarshalText() ([]byte, error) {
 	return []byte(uint128.String()), nil
 }
 
+// AppendText implements the encoding.TextAppender interface.
+func (uint128 Uint128) AppendText(b []byte) ([]byte, error) {
+	if uint128.IsZero() {
+		return append(b, ZeroStr...), nil
+	}
+
+	buf := [MaxStrLen]byte{}
+	return append(b, uint128.StringToBuf(buf[:])...), nil
+}
+
 // UnmarshalText implements the encoding.TextUnmarshaler interface.
 func (uint128 *Uint128) UnmarshalText(b []byte) error {
 	if len(b) == 0 {
@@ -17,3 +34,48 @@ func (uint128 *Uint128) UnmarshalText(b []byte) error {
 	_, err := fmt.Sscan(string(b), uint128)
 	return err
 }
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The encoding is a version byte followed by the value as an unsigned varint.
+func (uint128 Uint128) MarshalBinary() ([]byte, error) {
+	return uint128.AppendBinary(make([]byte, 0, 8))
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface.
+func (uint128 Uint128) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, BinaryVersion)
+	return uint128.AppendUvarint(b), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (uint128 *Uint128) UnmarshalBinary(b []byte) error {
+	if len(b) < 2 {
+		return errors.NotEnoughBytes.Value()
+	}
+
+	if b[0] != BinaryVersion {
+		return errors.InvalidFormat.Value()
+	}
+
+	u, n, err := FromUvarint(b[1:])
+	if err != errors.None {
+		return err.Value()
+	}
+
+	if n != len(b)-1 {
+		return errors.InvalidFormat.Value()
+	}
+
+	*uint128 = u
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+func (uint128 Uint128) GobEncode() ([]byte, error) {
+	return uint128.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+func (uint128 *Uint128) GobDecode(b []byte) error {
+	return uint128.UnmarshalBinary(b)
+}
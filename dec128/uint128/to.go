@@ -86,3 +86,14 @@ func (uint128 Uint128) StringToBuf(buf []byte) []byte {
 		}
 	}
 }
+
+// AppendUvarint appends the Uint128 to the byte slice bs as an unsigned varint, 7 bits per byte, least significant group first.
+// It uses between 1 and 19 bytes.
+func (uint128 Uint128) AppendUvarint(bs []byte) []byte {
+	u := uint128
+	for u.Hi != 0 || u.Lo >= 0x80 {
+		bs = append(bs, byte(u.Lo)|0x80)
+		u = Uint128{Lo: u.Lo>>7 | u.Hi<<57, Hi: u.Hi >> 7}
+	}
+	return append(bs, byte(u.Lo))
+}
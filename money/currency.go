@@ -0,0 +1,107 @@
+// Package money provides a currency aware amount type built on top of dec128,
+// backed by an embedded ISO 4217 currency table.
+package money
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+)
+
+//go:embed iso4217.json
+var iso4217 []byte
+
+// currencies is the ISO 4217 table indexed by alphabetic code.
+var currencies = loadCurrencies(iso4217)
+
+// Currency describes an ISO 4217 currency.
+type Currency struct {
+	// Code is the ISO 4217 alphabetic code, e.g. "USD".
+	Code string `json:"code"`
+
+	// Number is the ISO 4217 numeric code, e.g. "840".
+	Number string `json:"number"`
+
+	// Name is the english name of the currency.
+	Name string `json:"name"`
+
+	// MinorUnits is the number of digits after the decimal separator, e.g. 2 for USD and 0 for CLP.
+	MinorUnits uint8 `json:"minor"`
+
+	// CashIncrement is the smallest amount that can be paid in cash, e.g. 0.05 for CHF or 10 for CLP.
+	// When zero, cash payments use the minor unit.
+	CashIncrement dec128.Dec128 `json:"cash"`
+}
+
+// Lookup returns the currency registered in the ISO 4217 table under code.
+// The lookup is case insensitive.
+func Lookup(code string) (Currency, error) {
+	c, ok := currencies[strings.ToUpper(code)]
+	if !ok {
+		return Currency{}, NewCurrencyError(ErrUnknownCurrency, code)
+	}
+	return c, nil
+}
+
+// MustLookup is like Lookup but panics when the currency is unknown.
+// It is intended for package level variables and tests.
+func MustLookup(code string) Currency {
+	c, err := Lookup(code)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Currencies returns the codes of every currency in the ISO 4217 table.
+func Currencies() []string {
+	codes := make([]string, 0, len(currencies))
+	for code := range currencies {
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+// IsZero returns true when c is the zero Currency, meaning no currency was set.
+func (c Currency) IsZero() bool {
+	return c.Code == ""
+}
+
+// Scale returns the number of minor unit digits as int, the way CalculationConfiger.Scale expects it.
+func (c Currency) Scale() int {
+	return int(c.MinorUnits)
+}
+
+// Unit returns the smallest amount representable in the currency, e.g. 0.01 for USD.
+func (c Currency) Unit() dec128.Dec128 {
+	return dec128.New(dec128.One.Coefficient(), c.MinorUnits, false)
+}
+
+// CashUnit returns the smallest amount payable in cash.
+// It defaults to Unit when the currency has no cash rounding rule.
+func (c Currency) CashUnit() dec128.Dec128 {
+	if c.CashIncrement.IsZero() || c.CashIncrement.IsNaN() {
+		return c.Unit()
+	}
+	return c.CashIncrement
+}
+
+// String returns the alphabetic code of the currency.
+func (c Currency) String() string {
+	return c.Code
+}
+
+func loadCurrencies(data []byte) map[string]Currency {
+	var list []Currency
+	if err := json.Unmarshal(data, &list); err != nil {
+		panic("money: invalid embedded ISO 4217 table: " + err.Error())
+	}
+
+	table := make(map[string]Currency, len(list))
+	for _, c := range list {
+		table[c.Code] = c
+	}
+	return table
+}
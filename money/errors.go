@@ -0,0 +1,34 @@
+package money
+
+import "errors"
+
+var (
+	ErrUnknownCurrency  = errors.New("the currency is not in the ISO 4217 table")
+	ErrCurrencyMismatch = errors.New("the amounts are in different currencies")
+	ErrNoCurrency       = errors.New("the amount has no currency")
+	ErrInvalidRate      = errors.New("the exchange rate must be a positive number")
+	ErrRateNotFound     = errors.New("there is no exchange rate for the pair on that date")
+)
+
+type CurrencyError struct {
+	err error
+	msg string
+}
+
+func NewCurrencyError(err error, msg string) *CurrencyError {
+	return &CurrencyError{
+		err: err,
+		msg: msg,
+	}
+}
+
+func (ce *CurrencyError) Error() string {
+	if ce.msg == "" {
+		return "currency error: " + ce.err.Error()
+	}
+	return "currency error: " + ce.msg + " " + ce.err.Error()
+}
+
+func (ce *CurrencyError) Unwrap() error {
+	return ce.err
+}
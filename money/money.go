@@ -0,0 +1,155 @@
+package money
+
+import (
+	"encoding/json"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+)
+
+// Money is a Dec128 amount tied to an ISO 4217 currency.
+// Operations between amounts of different currencies fail with ErrCurrencyMismatch.
+type Money struct {
+	amount   dec128.Dec128
+	currency Currency
+}
+
+// New creates a Money from amount and the ISO 4217 alphabetic code.
+func New(amount dec128.Dec128, code string) (Money, error) {
+	c, err := Lookup(code)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: amount, currency: c}, nil
+}
+
+// NewFromCurrency creates a Money from amount and an already resolved currency.
+func NewFromCurrency(amount dec128.Dec128, c Currency) Money {
+	return Money{amount: amount, currency: c}
+}
+
+// Zero returns a zero amount in currency c.
+func Zero(c Currency) Money {
+	return Money{amount: dec128.Zero, currency: c}
+}
+
+// Amount returns the decimal amount, without rounding.
+func (m Money) Amount() dec128.Dec128 {
+	return m.amount
+}
+
+// Currency returns the currency of the amount.
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+// IsZero returns true if the amount is zero.
+func (m Money) IsZero() bool {
+	return m.amount.IsZero()
+}
+
+// IsNegative returns true if the amount is negative.
+func (m Money) IsNegative() bool {
+	return m.amount.IsNegative()
+}
+
+// SameCurrency returns true if both amounts are in the same currency.
+func (m Money) SameCurrency(other Money) bool {
+	return m.currency.Code == other.currency.Code
+}
+
+// Add returns m + other.
+// It fails with ErrCurrencyMismatch when the currencies differ.
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.assertSameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	return Money{amount: m.amount.Add(other.amount), currency: m.currency}, nil
+}
+
+// Sub returns m - other.
+// It fails with ErrCurrencyMismatch when the currencies differ.
+func (m Money) Sub(other Money) (Money, error) {
+	if err := m.assertSameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	return Money{amount: m.amount.Sub(other.amount), currency: m.currency}, nil
+}
+
+// Compare compares m and other the way Dec128.Compare does.
+// It fails with ErrCurrencyMismatch when the currencies differ.
+func (m Money) Compare(other Money) (int, error) {
+	if err := m.assertSameCurrency(other); err != nil {
+		return 0, err
+	}
+	return m.amount.Compare(other.amount), nil
+}
+
+// Mul returns m * factor, keeping the currency.
+func (m Money) Mul(factor dec128.Dec128) Money {
+	return Money{amount: m.amount.Mul(factor), currency: m.currency}
+}
+
+// Div returns m / divisor, keeping the currency.
+func (m Money) Div(divisor dec128.Dec128) Money {
+	return Money{amount: m.amount.Div(divisor), currency: m.currency}
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	return Money{amount: m.amount.Neg(), currency: m.currency}
+}
+
+// Round rounds the amount to the minor units of its currency using half away from zero.
+func (m Money) Round() Money {
+	return Money{amount: m.amount.RoundHalfAwayFromZero(m.currency.MinorUnits), currency: m.currency}
+}
+
+// String returns the amount with the minor units of its currency followed by the currency code, e.g. "12.50 USD".
+func (m Money) String() string {
+	a := m.amount
+	if !a.IsNaN() && a.Precision() < m.currency.MinorUnits {
+		a = a.Rescale(m.currency.MinorUnits)
+	}
+	if m.currency.IsZero() {
+		return a.StringFixed()
+	}
+	return a.StringFixed() + " " + m.currency.Code
+}
+
+type moneyJSON struct {
+	Amount   dec128.Dec128 `json:"amount"`
+	Currency string        `json:"currency"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: m.amount, Currency: m.currency.Code})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var v moneyJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	if v.Currency == "" {
+		return NewCurrencyError(ErrNoCurrency, string(data))
+	}
+
+	c, err := Lookup(v.Currency)
+	if err != nil {
+		return err
+	}
+
+	m.amount = v.Amount
+	m.currency = c
+	return nil
+}
+
+func (m Money) assertSameCurrency(other Money) error {
+	if !m.SameCurrency(other) {
+		return NewCurrencyError(ErrCurrencyMismatch, m.currency.Code+" vs "+other.currency.Code)
+	}
+	return nil
+}
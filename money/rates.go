@@ -0,0 +1,190 @@
+package money
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+)
+
+// DateLayout is the layout of the dates in JSON exchange rate files.
+const DateLayout = "2006-01-02"
+
+// ExchangeRate is the rate to convert amounts from one currency into another, valid since Date.
+type ExchangeRate struct {
+	From   string        // Source currency code
+	To     string        // Target currency code
+	Rate   dec128.Dec128 // Units of To for one unit of From
+	Date   time.Time     // Date since the rate is valid
+	Source string        // Who published the rate, e.g. "Banco Central de Chile"
+}
+
+// ExchangeRateProvider provides the rate to convert from one currency into another on a given date.
+type ExchangeRateProvider interface {
+	Rate(from, to string, on time.Time) (ExchangeRate, error)
+}
+
+type ratePair struct {
+	from string
+	to   string
+}
+
+// MemoryRates is an in memory ExchangeRateProvider.
+// For a given date it returns the latest rate published on or before that date.
+// It is safe for concurrent use.
+type MemoryRates struct {
+	mu    sync.RWMutex
+	rates map[ratePair][]ExchangeRate
+}
+
+// NewMemoryRates creates an empty MemoryRates.
+func NewMemoryRates() *MemoryRates {
+	return &MemoryRates{
+		rates: make(map[ratePair][]ExchangeRate),
+	}
+}
+
+// Add registers rate. A rate for the same pair and date replaces the previous one.
+func (mr *MemoryRates) Add(rate ExchangeRate) error {
+	if rate.Rate.IsNaN() || !rate.Rate.IsPositive() {
+		return NewCurrencyError(ErrInvalidRate, rate.From+"/"+rate.To+" "+rate.Rate.String())
+	}
+
+	rate.From = strings.ToUpper(rate.From)
+	rate.To = strings.ToUpper(rate.To)
+
+	if _, err := Lookup(rate.From); err != nil {
+		return err
+	}
+
+	if _, err := Lookup(rate.To); err != nil {
+		return err
+	}
+
+	rate.Date = truncateDate(rate.Date)
+	key := ratePair{rate.From, rate.To}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	list := mr.rates[key]
+	i := sort.Search(len(list), func(i int) bool { return !list[i].Date.Before(rate.Date) })
+
+	if i < len(list) && list[i].Date.Equal(rate.Date) {
+		list[i] = rate
+		return nil
+	}
+
+	list = append(list, ExchangeRate{})
+	copy(list[i+1:], list[i:])
+	list[i] = rate
+	mr.rates[key] = list
+
+	return nil
+}
+
+// Rate implements ExchangeRateProvider.
+// When only the opposite pair is registered, the inverse of its rate is returned.
+// Converting a currency into itself always uses a rate of one.
+func (mr *MemoryRates) Rate(from, to string, on time.Time) (ExchangeRate, error) {
+	from = strings.ToUpper(from)
+	to = strings.ToUpper(to)
+	on = truncateDate(on)
+
+	if from == to {
+		return ExchangeRate{From: from, To: to, Rate: dec128.One, Date: on}, nil
+	}
+
+	mr.mu.RLock()
+	defer mr.mu.RUnlock()
+
+	if r, ok := mr.find(from, to, on); ok {
+		return r, nil
+	}
+
+	if r, ok := mr.find(to, from, on); ok {
+		return ExchangeRate{From: from, To: to, Rate: dec128.One.Div(r.Rate), Date: r.Date, Source: r.Source}, nil
+	}
+
+	return ExchangeRate{}, NewCurrencyError(ErrRateNotFound, from+"/"+to+" on "+on.Format(DateLayout))
+}
+
+func (mr *MemoryRates) find(from, to string, on time.Time) (ExchangeRate, bool) {
+	list := mr.rates[ratePair{from, to}]
+	i := sort.Search(len(list), func(i int) bool { return list[i].Date.After(on) })
+	if i == 0 {
+		return ExchangeRate{}, false
+	}
+	return list[i-1], true
+}
+
+type rateJSON struct {
+	From   string        `json:"from"`
+	To     string        `json:"to"`
+	Rate   dec128.Dec128 `json:"rate"`
+	Date   string        `json:"date"`
+	Source string        `json:"source"`
+}
+
+// LoadJSONRates reads a JSON array of rates like
+//
+//	[{"from": "USD", "to": "CLP", "rate": "950.25", "date": "2024-03-15", "source": "BCCh"}]
+//
+// into a new MemoryRates.
+func LoadJSONRates(r io.Reader) (*MemoryRates, error) {
+	var list []rateJSON
+	if err := json.NewDecoder(r).Decode(&list); err != nil {
+		return nil, err
+	}
+
+	mr := NewMemoryRates()
+	for _, v := range list {
+		date, err := time.Parse(DateLayout, v.Date)
+		if err != nil {
+			return nil, err
+		}
+
+		err = mr.Add(ExchangeRate{From: v.From, To: v.To, Rate: v.Rate, Date: date, Source: v.Source})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return mr, nil
+}
+
+// LoadJSONRatesFile is like LoadJSONRates but reads the rates from the file at path.
+func LoadJSONRatesFile(path string) (*MemoryRates, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return LoadJSONRates(f)
+}
+
+// Convert returns m converted with rate and rounded to the minor units of the target currency.
+// It fails with ErrCurrencyMismatch when m is not in the source currency of rate.
+func (m Money) Convert(rate ExchangeRate) (Money, error) {
+	if !strings.EqualFold(m.currency.Code, rate.From) {
+		return Money{}, NewCurrencyError(ErrCurrencyMismatch, m.currency.Code+" vs "+rate.From)
+	}
+
+	to, err := Lookup(rate.To)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{amount: m.amount.Mul(rate.Rate), currency: to}.Round(), nil
+}
+
+func truncateDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/money"
+)
+
+func TestLookup(t *testing.T) {
+	type testCase struct {
+		code  string
+		minor uint8
+		unit  string
+		cash  string
+	}
+
+	testCases := [...]testCase{
+		{"USD", 2, "0.01", "0.01"},
+		{"usd", 2, "0.01", "0.01"},
+		{"CLP", 0, "1", "10"},
+		{"CHF", 2, "0.01", "0.05"},
+		{"KWD", 3, "0.001", "0.001"},
+		{"JPY", 0, "1", "1"},
+		{"CLF", 4, "0.0001", "0.0001"},
+	}
+
+	for _, tc := range testCases {
+		c, err := money.Lookup(tc.code)
+		if err != nil {
+			t.Fatalf("Lookup(%s): unexpected error %v", tc.code, err)
+		}
+		if c.MinorUnits != tc.minor {
+			t.Errorf("Lookup(%s): expected %d minor units, got %d", tc.code, tc.minor, c.MinorUnits)
+		}
+		if c.Unit().String() != tc.unit {
+			t.Errorf("Lookup(%s): expected unit %s, got %s", tc.code, tc.unit, c.Unit())
+		}
+		if c.CashUnit().String() != tc.cash {
+			t.Errorf("Lookup(%s): expected cash unit %s, got %s", tc.code, tc.cash, c.CashUnit())
+		}
+	}
+
+	if _, err := money.Lookup("XXY"); !errors.Is(err, money.ErrUnknownCurrency) {
+		t.Errorf("Lookup(XXY): expected %v, got %v", money.ErrUnknownCurrency, err)
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	usd := money.MustLookup("USD")
+	clp := money.MustLookup("CLP")
+
+	a := money.NewFromCurrency(dec128.FromString("10.255"), usd)
+	b := money.NewFromCurrency(dec128.FromString("0.745"), usd)
+
+	sum, err := a.Add(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum.String() != "11.000 USD" {
+		t.Errorf("expected 11.000 USD, got %s", sum)
+	}
+
+	diff, err := a.Sub(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.String() != "9.510 USD" {
+		t.Errorf("expected 9.510 USD, got %s", diff)
+	}
+
+	if r := a.Round(); r.String() != "10.26 USD" {
+		t.Errorf("expected 10.26 USD, got %s", r)
+	}
+
+	if r := money.NewFromCurrency(dec128.FromString("1234.5"), clp).Round(); r.String() != "1235 CLP" {
+		t.Errorf("expected 1235 CLP, got %s", r)
+	}
+
+	if r := money.NewFromCurrency(dec128.FromString("3"), usd); r.String() != "3.00 USD" {
+		t.Errorf("expected 3.00 USD, got %s", r)
+	}
+
+	c := money.NewFromCurrency(dec128.FromInt(1000), clp)
+
+	if _, err := a.Add(c); !errors.Is(err, money.ErrCurrencyMismatch) {
+		t.Errorf("expected %v adding USD to CLP, got %v", money.ErrCurrencyMismatch, err)
+	}
+
+	if _, err := a.Sub(c); !errors.Is(err, money.ErrCurrencyMismatch) {
+		t.Errorf("expected %v subtracting CLP from USD, got %v", money.ErrCurrencyMismatch, err)
+	}
+
+	if _, err := a.Compare(c); !errors.Is(err, money.ErrCurrencyMismatch) {
+		t.Errorf("expected %v comparing USD to CLP, got %v", money.ErrCurrencyMismatch, err)
+	}
+}
+
+func TestMoneyJson(t *testing.T) {
+	m, err := money.New(dec128.FromString("-12.5"), "EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(s) != `{"amount":"-12.5","currency":"EUR"}` {
+		t.Errorf("unexpected json %s", s)
+	}
+
+	var q money.Money
+	if err := json.Unmarshal(s, &q); err != nil {
+		t.Fatal(err)
+	}
+	if !q.Amount().Equal(m.Amount()) || q.Currency().Code != "EUR" {
+		t.Errorf("expected %s, got %s", m, q)
+	}
+
+	if err := json.Unmarshal([]byte(`{"amount":"1"}`), &q); !errors.Is(err, money.ErrNoCurrency) {
+		t.Errorf("expected %v, got %v", money.ErrNoCurrency, err)
+	}
+}
@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/money"
+)
+
+const ratesJSON = `[
+	{"from": "USD", "to": "CLP", "rate": "950.5", "date": "2024-03-15", "source": "BCCh"},
+	{"from": "USD", "to": "CLP", "rate": "948", "date": "2024-03-14", "source": "BCCh"},
+	{"from": "EUR", "to": "USD", "rate": "1.25", "date": "2024-03-01", "source": "ECB"}
+]`
+
+func day(s string) time.Time {
+	d, _ := time.Parse(money.DateLayout, s)
+	return d
+}
+
+func TestMemoryRates(t *testing.T) {
+	rates, err := money.LoadJSONRates(strings.NewReader(ratesJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type testCase struct {
+		from string
+		to   string
+		on   string
+		rate string
+		date string
+		err  error
+	}
+
+	testCases := [...]testCase{
+		{"USD", "CLP", "2024-03-15", "950.5", "2024-03-15", nil},
+		{"usd", "clp", "2024-03-14", "948", "2024-03-14", nil},
+		{"USD", "CLP", "2024-03-17", "950.5", "2024-03-15", nil},
+		{"USD", "CLP", "2024-03-13", "", "", money.ErrRateNotFound},
+		{"USD", "EUR", "2024-03-10", "0.8", "2024-03-01", nil},
+		{"CLP", "CLP", "2024-03-10", "1", "2024-03-10", nil},
+		{"CLP", "EUR", "2024-03-10", "", "", money.ErrRateNotFound},
+	}
+
+	for _, tc := range testCases {
+		r, err := rates.Rate(tc.from, tc.to, day(tc.on))
+		if tc.err != nil {
+			if !errors.Is(err, tc.err) {
+				t.Errorf("Rate(%s, %s, %s): expected error %v, got %v", tc.from, tc.to, tc.on, tc.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Rate(%s, %s, %s): unexpected error %v", tc.from, tc.to, tc.on, err)
+			continue
+		}
+		if r.Rate.String() != tc.rate || r.Date.Format(money.DateLayout) != tc.date {
+			t.Errorf("Rate(%s, %s, %s): expected %s since %s, got %s since %s", tc.from, tc.to, tc.on, tc.rate, tc.date, r.Rate, r.Date.Format(money.DateLayout))
+		}
+	}
+
+	if err := rates.Add(money.ExchangeRate{From: "USD", To: "CLP", Rate: dec128.FromInt(-1)}); !errors.Is(err, money.ErrInvalidRate) {
+		t.Errorf("expected %v adding a negative rate, got %v", money.ErrInvalidRate, err)
+	}
+}
+
+func TestMoneyConvert(t *testing.T) {
+	rate := money.ExchangeRate{From: "USD", To: "CLP", Rate: dec128.FromString("950.5")}
+
+	m := money.NewFromCurrency(dec128.FromString("57"), money.MustLookup("USD"))
+	c, err := m.Convert(rate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.String() != "54179 CLP" {
+		t.Errorf("expected 54179 CLP, got %s", c)
+	}
+
+	if _, err := c.Convert(rate); !errors.Is(err, money.ErrCurrencyMismatch) {
+		t.Errorf("expected %v converting CLP with a USD rate, got %v", money.ErrCurrencyMismatch, err)
+	}
+}
@@ -0,0 +1,111 @@
+package withdec128
+
+import (
+	"github.com/profe-ajedrez/badassitron/dec128"
+)
+
+// BracketMode defines how the brackets of a Bracketed tax apply to its base.
+type BracketMode int8
+
+const (
+	BracketsMarginal  BracketMode = 0 // Each part of the base at the rate of the bracket it falls in
+	BracketsWholeBase BracketMode = 1 // The whole base at the rate of the bracket it falls in
+)
+
+// TaxBracket is the rate of a Bracketed tax for the bases up to UpTo.
+// A bracket starts right after the UpTo of the previous one, the first one starts at zero.
+type TaxBracket struct {
+	UpTo dec128.Dec128 // Largest base of the bracket. Zero in the last bracket means no limit
+	Rate dec128.Dec128 // Percentage of the bracket
+}
+
+// TaxBrackets are the rates of a Bracketed tax, e.g. a luxury vehicle tax or a withholding.
+//
+// For example, with the brackets up to 1000 at 0% and over 1000 at 10%, the tax on 1500 is 1000 * 0% + 500 * 10% = 50
+// in BracketsMarginal mode, and 1500 * 10% = 150 in BracketsWholeBase mode.
+type TaxBrackets struct {
+	Mode     BracketMode  // How the brackets apply
+	Brackets []TaxBracket // Brackets in increasing UpTo order, the last one without limit
+}
+
+// BracketUsage is the part of the base of a tax taxed at a bracket.
+type BracketUsage struct {
+	Bracket int           // Index of the bracket
+	Base    dec128.Dec128 // Part of the base taxed at the bracket
+	Rate    dec128.Dec128 // Percentage of the bracket
+	Amount  dec128.Dec128 // Base * Rate / 100
+}
+
+// Validate checks the brackets are in increasing UpTo order with only the last one, and at least it, unlimited,
+// and their rates between 0 and 100.
+func (tb *TaxBrackets) Validate() error {
+	if tb == nil || len(tb.Brackets) == 0 || !tb.Brackets[len(tb.Brackets)-1].UpTo.IsZero() {
+		return ErrInvalidBrackets
+	}
+
+	if tb.Mode != BracketsMarginal && tb.Mode != BracketsWholeBase {
+		return ErrInvalidBrackets
+	}
+
+	for i, b := range tb.Brackets {
+		if b.Rate.IsNaN() || b.Rate.IsNegative() || b.Rate.GreaterThan(Hundred()) {
+			return ErrInvalidBrackets
+		}
+
+		if i == len(tb.Brackets)-1 {
+			continue
+		}
+
+		if b.UpTo.IsNaN() || !b.UpTo.IsPositive() || (i > 0 && b.UpTo.LessThanOrEqual(tb.Brackets[i-1].UpTo)) {
+			return ErrInvalidBrackets
+		}
+	}
+
+	return nil
+}
+
+// Calc returns the tax on base and the parts of it taxed at each bracket, just one in BracketsWholeBase mode.
+// The brackets must be valid.
+func (tb *TaxBrackets) Calc(base dec128.Dec128) (dec128.Dec128, []BracketUsage) {
+	bracket := len(tb.Brackets) - 1
+	for i, b := range tb.Brackets {
+		if b.UpTo.IsZero() || base.LessThanOrEqual(b.UpTo) {
+			bracket = i
+			break
+		}
+	}
+
+	var usage []BracketUsage
+	if tb.Mode == BracketsMarginal {
+		from := Zero()
+		for i, b := range tb.Brackets[:bracket+1] {
+			upTo := base
+			if i < bracket {
+				upTo = b.UpTo
+			}
+			usage = append(usage, newBracketUsage(i, upTo.Sub(from), b.Rate))
+			from = b.UpTo
+		}
+	} else {
+		usage = []BracketUsage{newBracketUsage(bracket, base, tb.Brackets[bracket].Rate)}
+	}
+
+	amount := Zero()
+	for _, u := range usage {
+		amount = amount.Add(u.Amount)
+	}
+
+	return amount, usage
+}
+
+func newBracketUsage(bracket int, base, rate dec128.Dec128) BracketUsage {
+	return BracketUsage{Bracket: bracket, Base: base, Rate: rate, Amount: base.Mul(rate.Div(dec128.Decimal100))}
+}
+
+// bracketsOf returns the brackets of tx, nil when it has none.
+func bracketsOf(tx TaxInformer) *TaxBrackets {
+	if bt, ok := tx.(BracketedTaxInformer); ok {
+		return bt.TaxBrackets()
+	}
+	return nil
+}
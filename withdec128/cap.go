@@ -0,0 +1,61 @@
+package withdec128
+
+import (
+	"github.com/profe-ajedrez/badassitron/dec128"
+)
+
+// TaxCap bounds the amount of a tax, e.g. a stamp tax of at most a fixed amount, or an environmental fee with a floor.
+// It applies after the amount is calculated.
+type TaxCap struct {
+	Floor   dec128.Dec128 // Minimum amount of the tax, none when zero
+	Ceiling dec128.Dec128 // Maximum amount of the tax, none when zero
+	PerUnit bool          // Floor and Ceiling are per unit of the quantity instead of per line
+}
+
+// Validate checks the floor and ceiling are not negative, and the ceiling, if any, is not under the floor.
+func (c *TaxCap) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	if c.Floor.IsNaN() || c.Ceiling.IsNaN() || c.Floor.IsNegative() || c.Ceiling.IsNegative() {
+		return ErrInvalidTaxCap
+	}
+
+	if !c.Ceiling.IsZero() && c.Ceiling.LessThan(c.Floor) {
+		return ErrInvalidTaxCap
+	}
+
+	return nil
+}
+
+// Apply returns amount, the amount of the tax for a line of qty units, within the floor and ceiling,
+// and which of them it hit.
+func (c *TaxCap) Apply(amount, qty dec128.Dec128) (dec128.Dec128, CapHit) {
+	if c == nil {
+		return amount, CapNone
+	}
+
+	floor, ceiling := c.Floor, c.Ceiling
+	if c.PerUnit {
+		floor, ceiling = floor.Mul(qty), ceiling.Mul(qty)
+	}
+
+	if !floor.IsZero() && amount.LessThan(floor) {
+		return floor, CapFloor
+	}
+
+	if !ceiling.IsZero() && amount.GreaterThan(ceiling) {
+		return ceiling, CapCeiling
+	}
+
+	return amount, CapNone
+}
+
+// capOf returns the cap of tx, nil when it has none.
+func capOf(tx TaxInformer) *TaxCap {
+	if tc, ok := tx.(TaxCapper); ok {
+		return tc.TaxCap()
+	}
+	return nil
+}
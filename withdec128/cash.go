@@ -0,0 +1,34 @@
+package withdec128
+
+import (
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/money"
+)
+
+// CashRounding defines how the payable total of a sale paid in cash is rounded.
+type CashRounding struct {
+	Increment dec128.Dec128       // Smallest payable amount, e.g. 0.05 or 10. When zero, the cash unit of the currency is used
+	Mode      dec128.RoundingMode // How totals between two increments are rounded
+}
+
+// CashRoundingFor returns the cash rounding of currency c, rounding half away from zero.
+func CashRoundingFor(c money.Currency) CashRounding {
+	return CashRounding{Increment: c.CashUnit(), Mode: dec128.RoundingHalfAwayFromZero}
+}
+
+// ChileanCashRounding returns the chilean rule for cash payments, after the 1 and 5 peso coins were removed:
+// totals ending in 1 to 5 are rounded down, and totals ending in 6 to 9 are rounded up, to the nearest 10.
+func ChileanCashRounding() CashRounding {
+	return CashRounding{Increment: dec128.Decimal10, Mode: dec128.RoundingHalfTowardZero}
+}
+
+// SwissCashRounding returns the swiss rule for cash payments: totals are rounded to the nearest 0.05.
+func SwissCashRounding() CashRounding {
+	return CashRounding{Increment: dec128.FromString("0.05"), Mode: dec128.RoundingHalfAwayFromZero}
+}
+
+// Round returns gross rounded to the increment of the rule, and the adjustment applied.
+func (cr CashRounding) Round(gross dec128.Dec128) (payable, adjustment dec128.Dec128) {
+	payable = gross.RoundToIncrement(cr.Increment, cr.Mode)
+	return payable, payable.Sub(gross)
+}
@@ -0,0 +1,173 @@
+package withdec128
+
+import "github.com/profe-ajedrez/badassitron/dec128"
+
+// ChargeKind defines how the amount of a charge is given.
+type ChargeKind int8
+
+// ChargeScope defines what the amount of a fixed charge is given for.
+type ChargeScope int8
+
+const (
+	ChargePercent ChargeKind = 0 // Percentage of the net of the line
+	ChargeFixed   ChargeKind = 1 // Fixed amount
+
+	ChargePerUnit     ChargeScope = 0 // Amount for each unit of the quantity
+	ChargePerLine     ChargeScope = 1 // Amount for the line
+	ChargePerDocument ChargeScope = 2 // Amount for the whole document, split among its lines with AllocateTo
+)
+
+// Charge is a surcharge or fee added to a line which is not a tax, e.g. a service charge, a tip or an environmental fee.
+// Taxable charges are added to the net, and the taxes apply on them. The rest are only added to the gross.
+// A fixed charge for the whole document is split among its lines with AllocateTo, or with Freight for its freight.
+type Charge struct {
+	Code           string        // Charge code
+	Name           string        // Charge name
+	Kind           ChargeKind    // Percentage or fixed amount
+	Value          dec128.Dec128 // Percentage, or fixed amount for the Scope
+	Scope          ChargeScope   // What a fixed amount is for, ignored by percentages
+	Taxable        bool          // The taxes of the line apply on the charge
+	BeforeDiscount bool          // A percentage applies on the net before discounts
+	Freight        bool          // The charge is a share of the freight of the document
+}
+
+// Validate checks the charge is not negative and of a known kind and scope.
+func (c Charge) Validate() error {
+	if c.Value.IsNaN() || c.Value.IsNegative() {
+		return NewChargeError(ErrNegativeCharge, c.Code)
+	}
+
+	if c.Kind != ChargePercent && c.Kind != ChargeFixed {
+		return NewChargeError(ErrInvalidCharge, c.Code)
+	}
+
+	if c.Scope != ChargePerUnit && c.Scope != ChargePerLine && c.Scope != ChargePerDocument {
+		return NewChargeError(ErrInvalidCharge, c.Code)
+	}
+
+	return nil
+}
+
+// AllocateTo splits the fixed document charge among lines in proportion to their net, with prec decimals or the ones
+// of the value if more, so the shares add up to the value. Each share is added to the charges of its line as a charge
+// for the line, taxable or not as c is. Lines must implement ChargeAdder.
+//
+// It returns the shares, in the order of lines.
+func (c Charge) AllocateTo(prec uint8, lines ...Enterable) ([]dec128.Dec128, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	if c.Kind != ChargeFixed || c.Scope != ChargePerDocument {
+		return nil, NewChargeError(ErrInvalidCharge, c.Code)
+	}
+
+	return allocateCharge(c.Value, AllocateByValue, "", prec, lines, c.share, ErrInvalidCharge)
+}
+
+// share returns share of the document charge c as a charge for a line.
+func (c Charge) share(share dec128.Dec128) Charge {
+	c.Value = share
+	c.Scope = ChargePerLine
+	return c
+}
+
+// Calc returns the amount of the charge for a line of qty units with the net values net and netWD,
+// and the base a percentage applied on.
+func (c Charge) Calc(net, netWD, qty dec128.Dec128) (amount, base dec128.Dec128) {
+	if c.Kind == ChargePercent {
+		base = net
+		if c.BeforeDiscount {
+			base = netWD
+		}
+		return base.Mul(c.Value.Div(dec128.Decimal100)), base
+	}
+
+	if c.Scope == ChargePerUnit {
+		return c.Value.Mul(qty), Zero()
+	}
+
+	return c.Value, Zero()
+}
+
+// DetailCharge is the detail of a charge added to a line.
+type DetailCharge struct {
+	code    string
+	name    string
+	percent dec128.Dec128
+	base    dec128.Dec128
+	amount  dec128.Dec128
+	taxable bool
+	freight bool
+}
+
+// NewDetailCharge returns the detail of c, amounting to amount on base.
+func NewDetailCharge(c Charge, amount, base dec128.Dec128) *DetailCharge {
+	dc := &DetailCharge{code: c.Code, name: c.Name, percent: Zero(), base: base, amount: amount, taxable: c.Taxable, freight: c.Freight}
+	if c.Kind == ChargePercent {
+		dc.percent = c.Value
+	}
+	return dc
+}
+
+func (dc *DetailCharge) Code() string {
+	return dc.code
+}
+
+func (dc *DetailCharge) Name() string {
+	return dc.name
+}
+
+// Percent returns the percentage of the charge, zero for a fixed charge.
+func (dc *DetailCharge) Percent() dec128.Dec128 {
+	return dc.percent
+}
+
+// Base returns the net the percentage of the charge applied on, zero for a fixed charge.
+func (dc *DetailCharge) Base() dec128.Dec128 {
+	return dc.base
+}
+
+func (dc *DetailCharge) Amount() dec128.Dec128 {
+	return dc.amount
+}
+
+// Taxable tells if the taxes of the line apply on the charge.
+func (dc *DetailCharge) Taxable() bool {
+	return dc.taxable
+}
+
+// Freight tells if the charge is a share of the freight of the document.
+func (dc *DetailCharge) Freight() bool {
+	return dc.freight
+}
+
+func (dc *DetailCharge) WithCode(code string) {
+	dc.code = code
+}
+
+func (dc *DetailCharge) WithName(nm string) {
+	dc.name = nm
+}
+
+func (dc *DetailCharge) WithPercent(v dec128.Dec128) {
+	dc.percent = v
+}
+
+func (dc *DetailCharge) WithBase(v dec128.Dec128) {
+	dc.base = v
+}
+
+func (dc *DetailCharge) WithAmount(v dec128.Dec128) {
+	dc.amount = v
+}
+
+func (dc *DetailCharge) WithTaxable(t bool) {
+	dc.taxable = t
+}
+
+func (dc *DetailCharge) WithFreight(f bool) {
+	dc.freight = f
+}
+
+var _ ChargeDetailer = &DetailCharge{}
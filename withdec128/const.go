@@ -5,6 +5,10 @@ import "github.com/profe-ajedrez/badassitron/dec128"
 type Stage int8
 type Type int8
 type Mode int8
+type Tender int8
+type TaxBase int8
+type ExemptionKind int8
+type CapHit int8
 
 const (
 	Natural Stage = 0
@@ -14,9 +18,28 @@ const (
 	Percentual Type = 0
 	Amount     Type = 1
 	AmountLine Type = 2
+	Specific   Type = 3
+	Bracketed  Type = 4
 
 	FromUV    = 0
 	FromGross = 1
+
+	TenderOther    Tender = 0
+	TenderCash     Tender = 1
+	TenderCard     Tender = 2
+	TenderTransfer Tender = 3
+
+	BaseNet       TaxBase = 0 // Net after discounts
+	BaseNetWD     TaxBase = 1 // Net before discounts
+	BaseReference TaxBase = 2 // Reference unit price times the quantity
+	BaseFixed     TaxBase = 3 // Amount given for the whole line
+
+	Exempt    ExemptionKind = 0 // Outside the scope of the tax
+	ZeroRated ExemptionKind = 1 // Within the scope of the tax, at a rate of zero
+
+	CapNone    CapHit = 0 // The amount of the tax was within its cap
+	CapFloor   CapHit = 1 // The amount of the tax was raised to its floor
+	CapCeiling CapHit = 2 // The amount of the tax was lowered to its ceiling
 )
 
 func Zero() dec128.Dec128    { return dec128.Decimal0.Copy() }
@@ -3,15 +3,44 @@ package withdec128
 import "errors"
 
 var (
-	ErrNilArgument         = errors.New("se esperaban los argumentos de entrada config, inputy output, pero uno o más son nil ¿Esta seguro de estar invocando la cadena de responsabilidad en el orden correcto?")
-	ErrNegativeUnitary     = errors.New("el unitario es negativo")
-	ErrNegativeQty         = errors.New("la cantidad es negativa")
-	ErrTaxOver100          = NewTaxError(errors.New("el impuesto porcentual es mayor a 100"), "")
-	ErrNegativeTax         = NewTaxError(errors.New("se detecto un impuesto negativo. El valor del impuesto no puede ser negativo, ya sea porcentual o de monto"), "")
-	ErrInvalidTaxType      = NewTaxError(errors.New("el impuesto se indica de un tipo invalido, debe ser: percentual, amount o amount_line"), "")
-	ErrTaxStageOutOfBounds = NewTaxError(errors.New("tax stage of detail tax is out of defined limits"), "")
-	ErrZeroQty             = errors.New("si quieres vender cantidad cero 0 ¿Que maiz paloma quieres vender?")
-	ErrInvalidTaxStage     = errors.New("tax stage of detail tax is invalid")
+	ErrNilArgument            = errors.New("se esperaban los argumentos de entrada config, inputy output, pero uno o más son nil ¿Esta seguro de estar invocando la cadena de responsabilidad en el orden correcto?")
+	ErrNegativeUnitary        = errors.New("el unitario es negativo")
+	ErrNegativeQty            = errors.New("la cantidad es negativa")
+	ErrTaxOver100             = NewTaxError(errors.New("el impuesto porcentual es mayor a 100"), "")
+	ErrNegativeTax            = NewTaxError(errors.New("se detecto un impuesto negativo. El valor del impuesto no puede ser negativo, ya sea porcentual o de monto"), "")
+	ErrInvalidTaxType         = NewTaxError(errors.New("el impuesto se indica de un tipo invalido, debe ser: percentual, amount, amount_line o specific o bracketed"), "")
+	ErrTaxStageOutOfBounds    = NewTaxError(errors.New("tax stage of detail tax is out of defined limits"), "")
+	ErrZeroQty                = errors.New("si quieres vender cantidad cero 0 ¿Que maiz paloma quieres vender?")
+	ErrInvalidTaxStage        = errors.New("tax stage of detail tax is invalid")
+	ErrNoCurrency             = errors.New("no se pudo determinar la moneda del cálculo, use CurrencyInput u Options.Curr")
+	ErrNotCashRoundable       = errors.New("el output no puede guardar el total a pagar redondeado, debe implementar CashRoundable")
+	ErrNotReportable          = errors.New("el output no puede guardar la conversión a la moneda de reporte, debe implementar ReportingBinder")
+	ErrNoProduct              = errors.New("la entrada no indica el producto, debe implementar ProductInformer")
+	ErrProductNotPriced       = errors.New("el producto no tiene precios en la lista de precios")
+	ErrQtyOverTiers           = errors.New("la cantidad supera el último tramo de la lista de precios")
+	ErrInvalidTiers           = errors.New("los tramos de precio deben estar en orden creciente de cantidad, y solo el último puede no tener límite")
+	ErrNotPriceable           = errors.New("el output no puede guardar el detalle del precio, debe implementar PricingBinder")
+	ErrInvalidUoM             = errors.New("la unidad de medida debe tener código, unidad base distinta de sí misma y un factor positivo")
+	ErrUnknownUoM             = errors.New("la unidad de medida no está registrada")
+	ErrUoMMismatch            = errors.New("las unidades de medida no tienen la misma unidad base")
+	ErrNotUnitConvertible     = errors.New("la entrada no puede cambiar de unidad de medida, debe implementar UnitNormalizable")
+	ErrNotUnitReportable      = errors.New("el output no puede guardar las unidades de medida, debe implementar UnitBinder")
+	ErrNoFormula              = errors.New("el impuesto específico no tiene fórmula, debe implementar SpecificTaxInformer")
+	ErrMissingAttribute       = errors.New("falta un atributo de la línea requerido por el impuesto específico")
+	ErrInvalidAttribute       = errors.New("el atributo de la línea es negativo o no es un número")
+	ErrInvalidExemption       = errors.New("la exención debe indicar el código del impuesto y ser exempt o zero_rated")
+	ErrNotExemptionReportable = errors.New("el output no puede guardar las bases exentas, debe implementar ExemptionBinder")
+	ErrNotReverseChargeable   = errors.New("el output no puede guardar la inversión del sujeto pasivo, debe implementar ReverseChargeBinder")
+	ErrNegativeCharge         = errors.New("el valor del cargo no puede ser negativo")
+	ErrInvalidCharge          = errors.New("el cargo debe ser percent o fixed, por unidad, línea o documento")
+	ErrDocumentCharge         = errors.New("el cargo fijo por documento debe repartirse entre las líneas con AllocateTo")
+	ErrNotChargeable          = errors.New("el output no puede guardar los cargos, debe implementar ChargeBinder")
+	ErrInvalidFreight         = errors.New("el flete debe repartirse por valor, cantidad o peso, y alguna línea debe tener peso en el reparto")
+	ErrNotChargeAddable       = errors.New("la entrada no puede recibir cargos, debe implementar ChargeAdder")
+	ErrNotFreightReportable   = errors.New("el output no puede guardar el impuesto del flete, debe implementar FreightBinder")
+	ErrInvalidBrackets        = NewTaxError(errors.New("los tramos del impuesto deben estar en orden creciente de base, solo el último sin límite, y sus tasas entre 0 y 100"), "")
+	ErrInvalidTaxCap          = NewTaxError(errors.New("el mínimo y el máximo del impuesto no pueden ser negativos, y el máximo no puede ser menor al mínimo"), "")
+	ErrInvalidTaxBase         = NewTaxError(errors.New("la base del impuesto no es válida, debe ser: net, net_wd, reference o fixed, y el valor de la base no puede ser negativo"), "")
 )
 
 type baseError struct {
@@ -134,3 +163,27 @@ func (de *DiscountError) Error() string {
 func (de *DiscountError) Unwrap() error {
 	return de.err
 }
+
+type ChargeError struct {
+	baseError
+}
+
+func NewChargeError(err error, msg string) *ChargeError {
+	return &ChargeError{
+		baseError: baseError{
+			err: err,
+			msg: msg,
+		},
+	}
+}
+
+func (ce *ChargeError) Error() string {
+	if ce.msg == "" {
+		return "charge error: " + ce.err.Error()
+	}
+	return "charge error: " + ce.msg + " " + ce.err.Error()
+}
+
+func (ce *ChargeError) Unwrap() error {
+	return ce.err
+}
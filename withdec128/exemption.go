@@ -0,0 +1,68 @@
+package withdec128
+
+import (
+	"strings"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+)
+
+// Exemption frees a line, or every line of a customer, from a tax.
+// Exempt and zero-rated taxes both amount to zero, but are reported apart.
+type Exemption struct {
+	TaxCode     string        // Code of the tax not charged, case insensitive
+	Kind        ExemptionKind // Exempt or ZeroRated
+	Certificate string        // Reference of the exemption certificate, if any
+	Reason      string        // Why the tax is not charged, e.g. the article of the law
+}
+
+// Exemptions is a set of exemption rules.
+type Exemptions []Exemption
+
+// For returns the first exemption for the tax code.
+func (e Exemptions) For(code string) (Exemption, bool) {
+	for _, ex := range e {
+		if strings.EqualFold(ex.TaxCode, code) {
+			return ex, true
+		}
+	}
+	return Exemption{}, false
+}
+
+// Validate checks every exemption names a tax and is of a known kind.
+func (e Exemptions) Validate() error {
+	for _, ex := range e {
+		if ex.TaxCode == "" || (ex.Kind != Exempt && ex.Kind != ZeroRated) {
+			return NewTaxError(ErrInvalidExemption, ex.TaxCode)
+		}
+	}
+	return nil
+}
+
+// ExemptTax returns tx charging nothing because of ex.
+// It keeps everything else about tx and implements ExemptTaxInformer.
+func ExemptTax(tx TaxInformer, ex Exemption) TaxInformer {
+	return &exemptTax{TaxInformer: tx, exemption: ex}
+}
+
+// exemptTax is a tax not charged because of an exemption.
+type exemptTax struct {
+	TaxInformer
+	exemption Exemption
+}
+
+// Value implements TaxInformer.
+func (et *exemptTax) Value() dec128.Dec128 {
+	return Zero()
+}
+
+// TaxBrackets implements BracketedTaxInformer, with a single bracket at zero for an exempt Bracketed tax.
+func (et *exemptTax) TaxBrackets() *TaxBrackets {
+	return &TaxBrackets{Brackets: []TaxBracket{{}}}
+}
+
+// Exemption implements ExemptTaxInformer.
+func (et *exemptTax) Exemption() *Exemption {
+	return &et.exemption
+}
+
+var _ ExemptTaxInformer = (*exemptTax)(nil)
@@ -0,0 +1,156 @@
+package withdec128
+
+import (
+	"github.com/profe-ajedrez/badassitron/dec128"
+)
+
+// AllocationKey defines how the freight of a document is split among its lines.
+type AllocationKey int8
+
+const (
+	AllocateByValue  AllocationKey = 0 // In proportion to the net of the lines, from their unit value, quantity and discount
+	AllocateByQty    AllocationKey = 1 // In proportion to the quantity of the lines
+	AllocateByWeight AllocationKey = 2 // In proportion to the quantity of the lines times the weight attribute of a unit
+)
+
+// Freight is the shipping of a whole document. It is split among the lines, and each share is taxed
+// at the taxes of the line it is given to, as the goods it carries are.
+type Freight struct {
+	Code   string        // Charge code of the shares
+	Name   string        // Charge name of the shares
+	Amount dec128.Dec128 // Freight of the document, before taxes
+	Key    AllocationKey // How the freight is split
+
+	// WeightAttribute is the attribute of the lines holding the weight of a unit, "weight" when empty.
+	// Used with AllocateByWeight, lines must implement AttributeInformer.
+	WeightAttribute string
+}
+
+// Validate checks the amount is not negative and the key is known.
+func (f Freight) Validate() error {
+	if f.Amount.IsNaN() || f.Amount.IsNegative() {
+		return NewChargeError(ErrNegativeCharge, f.Code)
+	}
+
+	if f.Key != AllocateByValue && f.Key != AllocateByQty && f.Key != AllocateByWeight {
+		return NewChargeError(ErrInvalidFreight, f.Code)
+	}
+
+	return nil
+}
+
+// AllocateTo splits the freight among lines by its key, with prec decimals or the ones of the amount if more,
+// so the shares add up to the amount. Each share is added to the charges of its line as a taxable freight charge,
+// so Charger adds it to the net and the taxes of the line apply on it. Lines must implement ChargeAdder.
+//
+// It returns the shares, in the order of lines.
+func (f Freight) AllocateTo(prec uint8, lines ...Enterable) ([]dec128.Dec128, error) {
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+
+	return allocateCharge(f.Amount, f.Key, f.WeightAttribute, prec, lines, f.Charge, ErrInvalidFreight)
+}
+
+// Charge returns share of the freight as a taxable charge.
+func (f Freight) Charge(share dec128.Dec128) Charge {
+	return Charge{
+		Code:    f.Code,
+		Name:    f.Name,
+		Kind:    ChargeFixed,
+		Value:   share,
+		Scope:   ChargePerLine,
+		Taxable: true,
+		Freight: true,
+	}
+}
+
+// allocateCharge splits amount among lines by key, with prec decimals or the ones of amount if more, and adds
+// the charge of each share to its line. Lines must implement ChargeAdder. It returns the shares, in the order of lines.
+// When amount can't be split, e.g. because no line has weight, the error wraps invalid.
+func allocateCharge(amount dec128.Dec128, key AllocationKey, attribute string, prec uint8, lines []Enterable,
+	charge func(dec128.Dec128) Charge, invalid error) ([]dec128.Dec128, error) {
+	weights := make([]dec128.Dec128, len(lines))
+	adders := make([]ChargeAdder, len(lines))
+
+	for i, line := range lines {
+		ca, ok := line.(ChargeAdder)
+		if !ok {
+			return nil, ErrNotChargeAddable
+		}
+		adders[i] = ca
+
+		w, err := allocationWeight(key, attribute, line)
+		if err != nil {
+			return nil, err
+		}
+		weights[i] = w
+	}
+
+	shares, err := amount.Allocate(weights, max(prec, amount.Precision()))
+	if err != nil {
+		return nil, NewChargeError(invalid, err.Error())
+	}
+
+	for i, share := range shares {
+		adders[i].AddCharge(charge(share))
+	}
+
+	return shares, nil
+}
+
+// allocationWeight returns the weight of line in a split by key. attribute names the attribute holding
+// the weight of a unit, "weight" when empty.
+func allocationWeight(key AllocationKey, attribute string, line Enterable) (dec128.Dec128, error) {
+	switch key {
+	case AllocateByQty:
+		return line.Qty(), nil
+	case AllocateByWeight:
+		name := attribute
+		if name == "" {
+			name = "weight"
+		}
+
+		ai, ok := line.(AttributeInformer)
+		if !ok {
+			return dec128.Dec128{}, NewChargeError(ErrMissingAttribute, name)
+		}
+
+		w, err := ai.Attributes().get(name)
+		if err != nil {
+			return dec128.Dec128{}, err
+		}
+		return line.Qty().Mul(w), nil
+	}
+
+	r := dec128.Decimal1.Sub(line.Discount().Div(dec128.Decimal100))
+	return line.UnitValue().Mul(line.Qty()).Mul(r), nil
+}
+
+// FreightTotals returns the freight charged on outputs and the part of their taxes due to it.
+// Outputs not implementing ChargeBinder and FreightBinder add nothing.
+func FreightTotals(outputs ...Outputable) (freight, tax dec128.Dec128) {
+	freight, tax = Zero(), Zero()
+
+	for _, out := range outputs {
+		if cb, ok := out.(ChargeBinder); ok {
+			freight = freight.Add(FreightOf(cb))
+		}
+		if fb, ok := out.(FreightBinder); ok {
+			tax = tax.Add(fb.FreightTax())
+		}
+	}
+
+	return freight, tax
+}
+
+// FreightOf returns the freight charged on the line of cb.
+func FreightOf(cb ChargeBinder) dec128.Dec128 {
+	freight := Zero()
+	for _, c := range cb.DetailCharges() {
+		if c.Freight() {
+			freight = freight.Add(c.Amount())
+		}
+	}
+	return freight
+}
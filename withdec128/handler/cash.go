@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"github.com/profe-ajedrez/badassitron/withdec128"
+)
+
+// CashRounder returns a stage rounding the payable total of cash sales according to rule. It must be placed after Grosser.
+//
+// Only sales whose input is a withdec128.Tenderer paid with withdec128.TenderCash are rounded, for the rest
+// the payable total is the gross value. The gross value and the taxes are never modified: the difference
+// is reported as the rounding adjustment. When the rule has no increment, the cash unit of the currency
+// of the calculation is used.
+func CashRounder(rule withdec128.CashRounding) withdec128.HandlerFunc {
+	return func(opts withdec128.CalculationConfiger, input withdec128.Enterable, output withdec128.Outputable, h ...withdec128.HandlerFunc) error {
+		if opts == nil || input == nil || output == nil {
+			return withdec128.ErrNilArgument
+		}
+
+		cr, ok := output.(withdec128.CashRoundable)
+		if !ok {
+			return withdec128.ErrNotCashRoundable
+		}
+
+		if t, ok := input.(withdec128.Tenderer); !ok || t.Tender() != withdec128.TenderCash {
+			cr.WithPayable(output.Gross())
+			cr.WithRoundingAdjustment(withdec128.Zero())
+			return Next(opts, input, output, h...)
+		}
+
+		if rule.Increment.IsZero() {
+			c := currencyOf(opts, output)
+			if c.IsZero() {
+				return withdec128.ErrNoCurrency
+			}
+			rule.Increment = c.CashUnit()
+		}
+
+		payable, adjustment := rule.Round(output.Gross())
+		cr.WithPayable(payable)
+		cr.WithRoundingAdjustment(adjustment)
+
+		return Next(opts, input, output, h...)
+	}
+}
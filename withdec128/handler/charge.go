@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/withdec128"
+)
+
+// Charger is the stage adding the charges of the input, which are not taxes, to the line.
+// It must be placed after Netter and before Taxer.
+//
+// Percentages apply on the net as Netter left it, or on the net before discounts. Taxable charges are added
+// to the net, before and after discounts, so the taxes apply on them and the discount is not changed.
+// The rest are added to the gross by Grosser. Inputs not implementing withdec128.ChargeInformer, or without
+// charges, are passed through. The output must implement withdec128.ChargeBinder. Fixed charges for the whole
+// document must have been split among the lines with withdec128.Charge.AllocateTo.
+func Charger(opts withdec128.CalculationConfiger, input withdec128.Enterable, output withdec128.Outputable, h ...withdec128.HandlerFunc) error {
+	if opts == nil || input == nil || output == nil {
+		return withdec128.ErrNilArgument
+	}
+
+	ci, ok := input.(withdec128.ChargeInformer)
+	if !ok || len(ci.Charges()) == 0 {
+		return Next(opts, input, output, h...)
+	}
+
+	cb, ok := output.(withdec128.ChargeBinder)
+	if !ok {
+		return withdec128.ErrNotChargeable
+	}
+
+	net, netWD := output.Net(), output.NetWD()
+	taxed, untaxed := withdec128.Zero(), withdec128.Zero()
+	details := make([]withdec128.ChargeDetailer, 0, len(ci.Charges()))
+
+	for _, c := range ci.Charges() {
+		if err := c.Validate(); err != nil {
+			return err
+		}
+
+		if c.Kind == withdec128.ChargeFixed && c.Scope == withdec128.ChargePerDocument {
+			return withdec128.NewChargeError(withdec128.ErrDocumentCharge, c.Code)
+		}
+
+		amount, base := c.Calc(net, netWD, input.Qty())
+		if c.Taxable {
+			taxed = taxed.Add(amount)
+		} else {
+			untaxed = untaxed.Add(amount)
+		}
+
+		details = append(details, withdec128.NewDetailCharge(c, amount, base))
+	}
+
+	if !taxed.IsZero() {
+		output.WithNet(net.Add(taxed))
+		output.WithNetWD(netWD.Add(taxed))
+
+		r, _ := output.Net().DivRound(input.Qty(), dec128.DefaultPrecision(), divisionRounding(opts))
+		output.WithDiscontedUnitary(r)
+	}
+
+	cb.WithCharges(details, taxed, untaxed)
+
+	return Next(opts, input, output, h...)
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/profe-ajedrez/badassitron/money"
+	"github.com/profe-ajedrez/badassitron/withdec128"
+)
+
+// CurrencyConverter returns a stage converting the computed output into the reporting currency to,
+// using the rate that provider gives for the date on. It must be placed after Grosser.
+//
+// The currency of the calculation is taken from the output, or from the options when the output does not know it.
+// The converted output, along with the rate used, is handed to the output, which must implement withdec128.ReportingBinder.
+func CurrencyConverter(provider money.ExchangeRateProvider, to string, on time.Time) withdec128.HandlerFunc {
+	return func(opts withdec128.CalculationConfiger, input withdec128.Enterable, output withdec128.Outputable, h ...withdec128.HandlerFunc) error {
+		if opts == nil || input == nil || output == nil {
+			return withdec128.ErrNilArgument
+		}
+
+		rb, ok := output.(withdec128.ReportingBinder)
+		if !ok {
+			return withdec128.ErrNotReportable
+		}
+
+		from := currencyOf(opts, output)
+		if from.IsZero() {
+			return withdec128.ErrNoCurrency
+		}
+
+		target, err := money.Lookup(to)
+		if err != nil {
+			return err
+		}
+
+		rate, err := provider.Rate(from.Code, target.Code, on)
+		if err != nil {
+			return err
+		}
+
+		rb.WithReporting(withdec128.ConvertOutput(output, rate, target))
+
+		return Next(opts, input, output, h...)
+	}
+}
+
+func currencyOf(opts withdec128.CalculationConfiger, output withdec128.Outputable) money.Currency {
+	if ci, ok := output.(withdec128.CurrencyInformer); ok && !ci.Currency().IsZero() {
+		return ci.Currency()
+	}
+
+	if ci, ok := opts.(withdec128.CurrencyInformer); ok {
+		return ci.Currency()
+	}
+
+	return money.Currency{}
+}
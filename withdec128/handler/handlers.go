@@ -49,6 +49,8 @@ func Bootstrap(opts withdec128.CalculationConfiger, input withdec128.Enterable,
 	output.WithQty(input.Qty())
 	output.WithDiscount(input.Discount())
 
+	bindCurrency(opts, input, output)
+
 	return Next(opts, input, output, h...)
 }
 
@@ -60,8 +62,49 @@ func Taxer(opts withdec128.CalculationConfiger, input withdec128.Enterable, outp
 	stages := withdec128.NewTaxStages()
 	detailTaxes := withdec128.NewDetailTaxes()
 
+	var attrs withdec128.Attributes
+	if ai, ok := input.(withdec128.AttributeInformer); ok {
+		attrs = ai.Attributes()
+	}
+
+	exemptions, err := exemptionsOf(opts, input)
+	if err != nil {
+		return err
+	}
+
+	var rc *withdec128.ReverseCharge
+	if ri, ok := opts.(withdec128.ReverseChargeInformer); ok {
+		rc = ri.ReverseCharge()
+	}
+
+	var exempted bool
+	reversed := make(map[int]bool)
 	for _, tax := range input.Taxes() {
-		err := stages.Bind(input.Qty(), tax)
+		var ex withdec128.Exemption
+		var isExempt bool
+		if tax != nil {
+			ex, isExempt = exemptions.For(tax.Code())
+		}
+
+		if isExempt {
+			tax = withdec128.ExemptTax(tax, ex)
+			exempted = true
+		} else if tax, err = withdec128.ResolveSpecificTax(tax, attrs); err != nil {
+			return err
+		}
+
+		if rc.Applies(tax) && !isExempt {
+			// Detailed, but left out of the stages the totals come from
+			if err = (&withdec128.TaxStage{}).Validate(tax); err != nil {
+				return err
+			}
+			reversed[tax.ID()] = true
+			detailTaxes.Bind(input.Qty(), tax)
+			detailTaxes.Reverse(tax.ID())
+			continue
+		}
+
+		err = stages.Bind(input.Qty(), tax)
 		if err != nil {
 			return err
 		}
@@ -70,12 +113,43 @@ func Taxer(opts withdec128.CalculationConfiger, input withdec128.Enterable, outp
 	}
 
 	output.WithTaxWD(
-		totalTaxes(stages, output.NetWD(), input.Qty()),
+		totalTaxes(stages, output.NetWD(), output.NetWD(), input.Qty()),
 	)
 	output.WithTax(
-		totalTaxes(stages, output.Net(), input.Qty()),
+		totalTaxes(stages, output.Net(), output.NetWD(), input.Qty()),
 	)
 
+	if err := freightTax(stages, output); err != nil {
+		return err
+	}
+
+	detailTaxes.CalcOn(output.Net(), output.Net(), output.NetWD(), input.Qty())
+	output.WithTaxes(detailTaxes.DetailTaxes())
+
+	if rc != nil {
+		rb, ok := output.(withdec128.ReverseChargeBinder)
+		if !ok {
+			return withdec128.ErrNotReverseChargeable
+		}
+
+		reverseTax := withdec128.Zero()
+		for _, d := range output.DetailTaxes() {
+			if !reversed[d.ID()] {
+				continue
+			}
+			reverseTax = reverseTax.Add(d.Amount())
+		}
+		rb.WithReverseCharge(rc.Mention, reverseTax)
+	}
+
+	if exempted {
+		eb, ok := output.(withdec128.ExemptionBinder)
+		if !ok {
+			return withdec128.ErrNotExemptionReportable
+		}
+		eb.WithExemptBases(exemptBases(output.DetailTaxes()))
+	}
+
 	opts.WithDetailTaxProcessor(detailTaxes)
 
 	return Next(opts, input, output, h...)
@@ -86,8 +160,16 @@ func Netter(opts withdec128.CalculationConfiger, input withdec128.Enterable, out
 		return withdec128.ErrNilArgument
 	}
 
+	mode := divisionRounding(opts)
+	prec := dec128.DefaultPrecision()
+
 	netWD := output.Unitary().Mul(output.Qty())
-	r := input.Discount().Div(dec128.Decimal100)
+	if la, ok := output.(withdec128.LineAmountInformer); ok {
+		if amount, ok := la.LineAmount(); ok {
+			netWD = amount
+		}
+	}
+	r, _ := input.Discount().DivRound(dec128.Decimal100, prec, mode)
 
 	discountRatio := dec128.Decimal1.Sub(r)
 	net := netWD.Mul(discountRatio)
@@ -96,7 +178,7 @@ func Netter(opts withdec128.CalculationConfiger, input withdec128.Enterable, out
 	output.WithNetWD(netWD)
 	output.WithNet(net)
 	output.WithDiscount(discount)
-	r = net.Div(input.Qty())
+	r, _ = net.DivRound(input.Qty(), prec, mode)
 	output.WithDiscontedUnitary(r)
 
 	return Next(opts, input, output, h...)
@@ -107,8 +189,13 @@ func Grosser(opts withdec128.CalculationConfiger, input withdec128.Enterable, ou
 		return withdec128.ErrNilArgument
 	}
 
-	output.WithGross(output.Tax().Add(output.Net()))
-	output.WithGrossWD(output.TaxWD().Add(output.NetWD()))
+	untaxed := withdec128.Zero()
+	if cb, ok := output.(withdec128.ChargeBinder); ok {
+		untaxed = cb.UntaxedCharges()
+	}
+
+	output.WithGross(output.Tax().Add(output.Net()).Add(untaxed))
+	output.WithGrossWD(output.TaxWD().Add(output.NetWD()).Add(untaxed))
 	output.WithGrossDiscount(output.GrossWD().Sub(output.Gross()))
 
 	return Next(opts, input, output, h...)
@@ -126,9 +213,98 @@ func Next(opts withdec128.CalculationConfiger, input withdec128.Enterable, outpu
 	return h[0](opts, input, output, h[1:]...)
 }
 
-func totalTaxes(stages *withdec128.Stages, taxable, qty dec128.Dec128) dec128.Dec128 {
-	natural := stages.Natural.Calc(taxable, qty)
-	overtax := stages.Overtax.Calc(taxable.Add(natural), qty)
-	bypass := stages.Bypass.Calc(taxable, qty)
+// exemptBases returns the taxable of each exempt and zero-rated tax of details, by tax code. The rest of the line
+// is not reported, as it may be taxed by the taxes not exempted.
+func exemptBases(details []withdec128.TaxDetailer) (exempt, zeroRated map[string]dec128.Dec128) {
+	exempt, zeroRated = make(map[string]dec128.Dec128), make(map[string]dec128.Dec128)
+	for _, d := range details {
+		ei, ok := d.(withdec128.ExemptTaxInformer)
+		if !ok || ei.Exemption() == nil {
+			continue
+		}
+
+		bases := exempt
+		if ei.Exemption().Kind == withdec128.ZeroRated {
+			bases = zeroRated
+		}
+		bases[d.Code()] = d.Taxable()
+	}
+	return exempt, zeroRated
+}
+
+// totalTaxes returns the taxes of the stages on taxable, but for the taxes declaring BaseNetWD, which apply on undiscounted.
+func totalTaxes(stages *withdec128.Stages, taxable, undiscounted, qty dec128.Dec128) dec128.Dec128 {
+	natural := stages.Natural.CalcOn(taxable, undiscounted, qty)
+	overtax := stages.Overtax.CalcOn(taxable.Add(natural), undiscounted.Add(natural), qty)
+	bypass := stages.Bypass.CalcOn(taxable, undiscounted, qty)
 	return natural.Add(overtax).Add(bypass)
 }
+
+// freightTax reports the part of the tax of the output due to the freight charged on it, the difference with
+// the taxes of the line without the freight. The output must implement withdec128.FreightBinder when it has freight.
+func freightTax(stages *withdec128.Stages, output withdec128.Outputable) error {
+	cb, ok := output.(withdec128.ChargeBinder)
+	if !ok {
+		return nil
+	}
+
+	freight := withdec128.Zero()
+	for _, c := range cb.DetailCharges() {
+		if c.Freight() && c.Taxable() {
+			freight = freight.Add(c.Amount())
+		}
+	}
+
+	if freight.IsZero() {
+		return nil
+	}
+
+	fb, ok := output.(withdec128.FreightBinder)
+	if !ok {
+		return withdec128.ErrNotFreightReportable
+	}
+
+	without := totalTaxes(stages, output.Net().Sub(freight), output.NetWD().Sub(freight), output.Qty())
+	fb.WithFreightTax(output.Tax().Sub(without))
+
+	return nil
+}
+
+// exemptionsOf returns the exemptions of the line followed by the ones of the customer set in the options,
+// so the ones of the line prevail.
+func exemptionsOf(opts withdec128.CalculationConfiger, input withdec128.Enterable) (withdec128.Exemptions, error) {
+	var exemptions withdec128.Exemptions
+
+	if ei, ok := input.(withdec128.ExemptionInformer); ok {
+		exemptions = append(exemptions, ei.Exemptions()...)
+	}
+	if ei, ok := opts.(withdec128.ExemptionInformer); ok {
+		exemptions = append(exemptions, ei.Exemptions()...)
+	}
+
+	return exemptions, exemptions.Validate()
+}
+
+// divisionRounding returns the rounding mode for quotients set in the options, or half away from zero.
+func divisionRounding(opts withdec128.CalculationConfiger) dec128.RoundingMode {
+	if dr, ok := opts.(withdec128.DivisionRounder); ok {
+		return dr.DivisionRounding()
+	}
+	return dec128.RoundingHalfAwayFromZero
+}
+
+// bindCurrency propagates the currency of the input, if it has one, to the options and the output.
+func bindCurrency(opts withdec128.CalculationConfiger, input withdec128.Enterable, output withdec128.Outputable) {
+	ci, ok := input.(withdec128.CurrencyInformer)
+	if !ok || ci.Currency().IsZero() {
+		return
+	}
+
+	if cb, ok := opts.(withdec128.CurrencyBinder); ok {
+		cb.WithCurrency(ci.Currency())
+	}
+
+	if cb, ok := output.(withdec128.CurrencyBinder); ok {
+		cb.WithCurrency(ci.Currency())
+	}
+}
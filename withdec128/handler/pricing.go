@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"github.com/profe-ajedrez/badassitron/withdec128"
+)
+
+// TierPricer returns a stage resolving the unit value of the input from the tiers of its product in a price list.
+// It must be placed after EntryValidation and before Bootstrap.
+//
+// The input must implement withdec128.ProductInformer. The first of lists having prices for the product is used,
+// and the unit value it gives replaces the one of the input for the rest of the stages. The input is given back its
+// own unit value once they are done. How the unit value was resolved, source list and tiers included, is handed to
+// the output, which must implement withdec128.PricingBinder. Netter takes the net from the priced amount, which
+// the unit value times the quantity may only approximate in graduated mode.
+func TierPricer(lists ...withdec128.PriceList) withdec128.HandlerFunc {
+	return func(opts withdec128.CalculationConfiger, input withdec128.Enterable, output withdec128.Outputable, h ...withdec128.HandlerFunc) error {
+		if opts == nil || input == nil || output == nil {
+			return withdec128.ErrNilArgument
+		}
+
+		pi, ok := input.(withdec128.ProductInformer)
+		if !ok || pi.Product() == "" {
+			return withdec128.ErrNoProduct
+		}
+
+		pb, ok := output.(withdec128.PricingBinder)
+		if !ok {
+			return withdec128.ErrNotPriceable
+		}
+
+		for _, list := range lists {
+			if !list.Has(pi.Product()) {
+				continue
+			}
+
+			pd, err := list.Price(pi.Product(), input.Qty(), divisionRounding(opts))
+			if err != nil {
+				return err
+			}
+
+			uv := input.UnitValue()
+			input.WithUnitValue(pd.UnitValue)
+			pb.WithPricing(pd)
+
+			err = Next(opts, input, output, h...)
+			input.WithUnitValue(uv)
+
+			return err
+		}
+
+		return withdec128.ErrProductNotPriced
+	}
+}
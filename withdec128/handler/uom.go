@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/withdec128"
+)
+
+// UoMNormalizer returns a stage expressing the quantity and unit value of the input in the base unit of its unit of measure,
+// so taxes by amount apply per base unit. It must be placed before Bootstrap, and after TierPricer when prices are per sold unit.
+//
+// The input must implement withdec128.UnitNormalizable, and inputs without unit of measure are left as they are.
+// The unit value per base unit is rounded at the default precision, but Netter takes the net from the sold units,
+// so it stays exact. The sold and base units are handed to the output, which must implement withdec128.UnitBinder.
+// The input is given back its own quantity, unit value and unit of measure once the rest of the stages are done.
+func UoMNormalizer(registry *withdec128.UoMRegistry) withdec128.HandlerFunc {
+	return func(opts withdec128.CalculationConfiger, input withdec128.Enterable, output withdec128.Outputable, h ...withdec128.HandlerFunc) error {
+		if opts == nil || input == nil || output == nil || registry == nil {
+			return withdec128.ErrNilArgument
+		}
+
+		un, ok := input.(withdec128.UnitNormalizable)
+		if !ok {
+			return withdec128.ErrNotUnitConvertible
+		}
+
+		ub, ok := output.(withdec128.UnitBinder)
+		if !ok {
+			return withdec128.ErrNotUnitReportable
+		}
+
+		if un.UnitOfMeasure() == "" {
+			return Next(opts, input, output, h...)
+		}
+
+		uom, err := registry.Lookup(un.UnitOfMeasure())
+		if err != nil {
+			return err
+		}
+
+		qty, uv, code := input.Qty(), input.UnitValue(), un.UnitOfMeasure()
+		baseUV, _ := uv.DivRound(uom.Factor, dec128.DefaultPrecision(), divisionRounding(opts))
+
+		ud := withdec128.UnitDetail{
+			SoldUnit:      uom.Code,
+			SoldQty:       qty,
+			SoldUnitValue: uv,
+			BaseUnit:      uom.Base,
+			BaseQty:       qty.Mul(uom.Factor),
+			BaseUnitValue: baseUV,
+			Factor:        uom.Factor,
+		}
+
+		un.WithQty(ud.BaseQty)
+		un.WithUnitOfMeasure(uom.Base)
+		input.WithUnitValue(ud.BaseUnitValue)
+		ub.WithUnits(ud)
+
+		err = Next(opts, input, output, h...)
+
+		un.WithQty(qty)
+		un.WithUnitOfMeasure(code)
+		input.WithUnitValue(uv)
+
+		return err
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/money"
 )
 
 type CalculationConfig struct {
@@ -42,11 +43,17 @@ func (cc *CalculationConfig) WithNoUnitValueNormalization() {
 }
 
 type Input struct {
-	UV      dec128.Dec128 // Unit Value
-	GT      dec128.Dec128 // Gross Total
-	QTY     dec128.Dec128 // Quantity
-	Disc    dec128.Dec128 // Discount
-	TaxList []*InputTax   // Taxes
+	UV         dec128.Dec128 // Unit Value
+	GT         dec128.Dec128 // Gross Total
+	QTY        dec128.Dec128 // Quantity
+	Disc       dec128.Dec128 // Discount
+	TaxList    []*InputTax   // Taxes
+	TenderType Tender        // How the sale is paid
+	ProductID  string        // Product sold, to look up its price in a price list
+	UoM        string        // Unit of measure of QTY and UV
+	Attrs      Attributes    // Physical attributes of one unit, for Specific taxes
+	Exempts    Exemptions    // Taxes the line is exempted from, over the ones of the customer
+	ChargeList []Charge      // Charges which are not taxes, e.g. a service charge
 }
 
 func (i *Input) UnitValue() dec128.Dec128 {
@@ -76,6 +83,51 @@ func (i *Input) Taxes() []TaxInformer {
 	return taxes
 }
 
+// Tender implements Tenderer.
+func (i *Input) Tender() Tender {
+	return i.TenderType
+}
+
+// Product implements ProductInformer.
+func (i *Input) Product() string {
+	return i.ProductID
+}
+
+// Attributes implements AttributeInformer.
+func (i *Input) Attributes() Attributes {
+	return i.Attrs
+}
+
+// Charges implements ChargeInformer.
+func (i *Input) Charges() []Charge {
+	return i.ChargeList
+}
+
+// AddCharge implements ChargeAdder.
+func (i *Input) AddCharge(c Charge) {
+	i.ChargeList = append(i.ChargeList, c)
+}
+
+// Exemptions implements ExemptionInformer.
+func (i *Input) Exemptions() Exemptions {
+	return i.Exempts
+}
+
+// UnitOfMeasure implements UnitNormalizable.
+func (i *Input) UnitOfMeasure() string {
+	return i.UoM
+}
+
+// WithUnitOfMeasure implements UnitNormalizable.
+func (i *Input) WithUnitOfMeasure(uom string) {
+	i.UoM = uom
+}
+
+// WithQty implements UnitNormalizable.
+func (i *Input) WithQty(qty dec128.Dec128) {
+	i.QTY = qty
+}
+
 func (i *Input) WithUnitValue(uv dec128.Dec128) {
 	i.UV = uv
 }
@@ -92,6 +144,17 @@ func (i *Input) SetDiscToHundred() {
 	i.Disc = dec128.FromInt(100)
 }
 
+// CurrencyInput is an Input whose amounts are expressed in Curr.
+type CurrencyInput struct {
+	Input
+	Curr money.Currency // Currency of the amounts
+}
+
+// Currency implements CurrencyInformer.
+func (ci *CurrencyInput) Currency() money.Currency {
+	return ci.Curr
+}
+
 type InputTax struct {
 	CodeValue string        // Tax code
 	NameValue string        // Tax name
@@ -99,6 +162,12 @@ type InputTax struct {
 	Id        int           // Tax ID
 	Typee     Type          // Tax type
 	Stagee    Stage         // Tax stage
+	Basee     TaxBase       // Base the tax applies on
+	BaseV     dec128.Dec128 // Reference unit price or line amount, depending on Basee
+	Cap       *TaxCap       // Floor and ceiling of the amount of the tax, if any
+	Brackets  *TaxBrackets  // Brackets of a Bracketed tax
+
+	Formula SpecificFormula `json:"-"` // How a Specific tax is computed from the attributes of the line
 }
 
 // Stage implements TaxInformer.
@@ -111,6 +180,31 @@ func (it *InputTax) Value() dec128.Dec128 {
 	return it.V
 }
 
+// Base implements TaxBaser.
+func (it *InputTax) Base() TaxBase {
+	return it.Basee
+}
+
+// BaseValue implements TaxBaser.
+func (it *InputTax) BaseValue() dec128.Dec128 {
+	return it.BaseV
+}
+
+// TaxCap implements TaxCapper.
+func (it *InputTax) TaxCap() *TaxCap {
+	return it.Cap
+}
+
+// TaxBrackets implements BracketedTaxInformer.
+func (it *InputTax) TaxBrackets() *TaxBrackets {
+	return it.Brackets
+}
+
+// SpecificFormula implements SpecificTaxInformer.
+func (it *InputTax) SpecificFormula() SpecificFormula {
+	return it.Formula
+}
+
 func (it *InputTax) ID() int {
 	return it.Id
 }
@@ -133,4 +227,17 @@ func (it *InputTax) String() string {
 }
 
 var _ Enterable = (*Input)(nil)
+var _ Tenderer = (*Input)(nil)
+var _ ProductInformer = (*Input)(nil)
+var _ UnitNormalizable = (*Input)(nil)
 var _ TaxInformer = (*InputTax)(nil)
+var _ SpecificTaxInformer = (*InputTax)(nil)
+var _ TaxBaser = (*InputTax)(nil)
+var _ TaxCapper = (*InputTax)(nil)
+var _ BracketedTaxInformer = (*InputTax)(nil)
+var _ AttributeInformer = (*Input)(nil)
+var _ ExemptionInformer = (*Input)(nil)
+var _ ChargeInformer = (*Input)(nil)
+var _ ChargeAdder = (*Input)(nil)
+var _ Enterable = (*CurrencyInput)(nil)
+var _ CurrencyInformer = (*CurrencyInput)(nil)
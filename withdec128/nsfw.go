@@ -1,6 +1,9 @@
 package withdec128
 
-import "github.com/profe-ajedrez/badassitron/dec128"
+import (
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/money"
+)
 
 type TaxStager interface {
 	Validate(tx TaxInformer) error
@@ -18,10 +21,12 @@ type TaxInformer interface {
 	Value() dec128.Dec128
 
 	// Type returns the type of the tax.
-	// It can be Percentual, Amount, or AmountLine.
+	// It can be Percentual, Amount, AmountLine or Specific.
 	// Percentual means the tax is a percentage of the unit value.
 	// Amount means the tax is a fixed amount.
 	// AmountLine means the tax is a fixed amount per line.
+	// Specific means the tax is an amount per unit computed from the attributes of the line.
+	// Bracketed means the tax applies rates by brackets of its base.
 	// This is used to determine how the tax is applied.
 	Type() Type
 
@@ -31,6 +36,91 @@ type TaxInformer interface {
 	String() string
 }
 
+// SpecificTaxInformer represents a Specific tax, which knows how to compute its amount per unit.
+type SpecificTaxInformer interface {
+	SpecificFormula() SpecificFormula
+}
+
+// TaxBaser represents a tax declaring the base it applies on. Taxes not implementing it apply on the net.
+type TaxBaser interface {
+	// Base returns the base the tax applies on.
+	Base() TaxBase
+
+	// BaseValue returns the reference unit price when Base is BaseReference,
+	// or the amount of the line when Base is BaseFixed.
+	BaseValue() dec128.Dec128
+}
+
+// ExemptionInformer represents a line, or the options of the calculation for a customer, knowing the taxes it is exempted from.
+type ExemptionInformer interface {
+	Exemptions() Exemptions
+}
+
+// ExemptTaxInformer represents a tax, or the detail of a tax, not charged because of an exemption.
+type ExemptTaxInformer interface {
+	Exemption() *Exemption
+}
+
+// ExemptionDetailer represents the detail of a tax able to keep the exemption it was not charged for.
+type ExemptionDetailer interface {
+	ExemptTaxInformer
+	WithExemption(*Exemption)
+}
+
+// ExemptionBinder represents an output able to keep the bases of its exempt and zero-rated taxes, by tax code.
+type ExemptionBinder interface {
+	// ExemptionBases returns the bases of the exempt and zero-rated taxes, by tax code.
+	ExemptionBases() (exempt, zeroRated map[string]dec128.Dec128)
+	WithExemptBases(exempt, zeroRated map[string]dec128.Dec128)
+}
+
+// ReverseChargeInformer represents options of a calculation that may be in reverse charge mode.
+type ReverseChargeInformer interface {
+	// ReverseCharge returns the reverse charge of the sale, or nil when the seller charges its taxes.
+	ReverseCharge() *ReverseCharge
+}
+
+// ReverseChargeBinder represents an output able to keep that its sale is reverse charged.
+type ReverseChargeBinder interface {
+	// ReverseCharge returns whether the sale is reverse charged, its legal mention and the reverse charged taxes.
+	ReverseCharge() (charged bool, mention string, tax dec128.Dec128)
+	WithReverseCharge(mention string, tax dec128.Dec128)
+}
+
+// ReverseChargeDetailer represents the detail of a tax able to keep that the buyer self-assesses it.
+type ReverseChargeDetailer interface {
+	ReverseCharged() bool
+	WithReverseCharged(bool)
+}
+
+// TaxCapper represents a tax whose amount is bounded by a floor, a ceiling or both.
+type TaxCapper interface {
+	// TaxCap returns the bounds of the tax, or nil when it has none.
+	TaxCap() *TaxCap
+}
+
+// CapDetailer represents the detail of a tax able to keep whether its amount hit its cap.
+type CapDetailer interface {
+	CapHit() CapHit
+	WithCapHit(CapHit)
+}
+
+// BracketedTaxInformer represents a Bracketed tax, which knows its brackets.
+type BracketedTaxInformer interface {
+	TaxBrackets() *TaxBrackets
+}
+
+// BracketDetailer represents the detail of a tax able to keep the parts of its base taxed at each bracket.
+type BracketDetailer interface {
+	Brackets() []BracketUsage
+	WithBrackets([]BracketUsage)
+}
+
+// AttributeInformer represents an input that knows the physical attributes of the product it sells.
+type AttributeInformer interface {
+	Attributes() Attributes
+}
+
 type DetailTaxProcessor interface {
 	Bind(qty dec128.Dec128, tx TaxInformer)
 	Calc(taxableToInform, taxableToCalculate, qty dec128.Dec128)
@@ -113,6 +203,49 @@ type TaxDetailer interface {
 	WithType(Type)
 }
 
+// ChargeInformer represents an input carrying charges which are not taxes, e.g. a service charge.
+type ChargeInformer interface {
+	Charges() []Charge
+}
+
+// ChargeBinder represents an output able to keep the charges added to the line.
+type ChargeBinder interface {
+	DetailCharges() []ChargeDetailer
+
+	// UntaxedCharges returns the charges added to the gross but not to the net.
+	UntaxedCharges() dec128.Dec128
+
+	WithCharges(details []ChargeDetailer, taxed, untaxed dec128.Dec128)
+}
+
+type ChargeDetailer interface {
+	Code() string
+	Name() string
+	Percent() dec128.Dec128
+	Base() dec128.Dec128
+	Amount() dec128.Dec128
+	Taxable() bool
+	Freight() bool
+	WithCode(string)
+	WithName(string)
+	WithPercent(dec128.Dec128)
+	WithBase(dec128.Dec128)
+	WithAmount(dec128.Dec128)
+	WithTaxable(bool)
+	WithFreight(bool)
+}
+
+// ChargeAdder represents an input to which charges can be added, e.g. its share of the freight of the document.
+type ChargeAdder interface {
+	AddCharge(Charge)
+}
+
+// FreightBinder represents an output able to keep the part of its taxes due to the freight charged on it.
+type FreightBinder interface {
+	FreightTax() dec128.Dec128
+	WithFreightTax(dec128.Dec128)
+}
+
 type DiscountDetailer interface {
 	Percent() dec128.Dec128
 	Amount() dec128.Dec128
@@ -124,4 +257,66 @@ type DiscountDetailer interface {
 	WithNet(v dec128.Dec128)
 }
 
+// CurrencyInformer represents something that knows in which currency its amounts are expressed.
+type CurrencyInformer interface {
+	Currency() money.Currency
+}
+
+// CurrencyBinder represents something that can be told in which currency its amounts are expressed.
+type CurrencyBinder interface {
+	WithCurrency(money.Currency)
+}
+
+// LineAmountInformer represents an output knowing the exact amount of its line before discounts, e.g. because it
+// was priced from a price list or sold in another unit of measure, which its unit value times its quantity may only approximate.
+type LineAmountInformer interface {
+	// LineAmount returns the amount of the line, and false when it is the unit value times the quantity.
+	LineAmount() (dec128.Dec128, bool)
+}
+
+// ProductInformer represents an input that knows which product it sells.
+type ProductInformer interface {
+	Product() string
+}
+
+// PricingBinder represents an output able to keep how its unit value was resolved from a price list.
+type PricingBinder interface {
+	WithPricing(PricingDetail)
+}
+
+// UnitNormalizable represents an input whose quantity can be expressed in another unit of measure.
+type UnitNormalizable interface {
+	UnitOfMeasure() string
+	WithUnitOfMeasure(string)
+	WithQty(dec128.Dec128)
+}
+
+// UnitBinder represents an output able to keep the units of measure the line was sold and calculated in.
+type UnitBinder interface {
+	WithUnits(UnitDetail)
+}
+
+// DivisionRounder represents options choosing how the quotients of the calculation are rounded.
+type DivisionRounder interface {
+	DivisionRounding() dec128.RoundingMode
+}
+
+// ReportingBinder represents an output able to keep its conversion into a reporting currency.
+type ReportingBinder interface {
+	WithReporting(*ReportingOutput)
+}
+
+// Tenderer represents a sale that knows how it is paid.
+type Tenderer interface {
+	Tender() Tender
+}
+
+// CashRoundable represents an output able to hold the cash rounded payable total.
+type CashRoundable interface {
+	Payable() dec128.Dec128
+	RoundingAdjustment() dec128.Dec128
+	WithPayable(dec128.Dec128)
+	WithRoundingAdjustment(dec128.Dec128)
+}
+
 type HandlerFunc func(CalculationConfiger, Enterable, Outputable, ...HandlerFunc) error
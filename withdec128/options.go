@@ -1,11 +1,19 @@
 package withdec128
 
-import "github.com/profe-ajedrez/badassitron/dec128"
+import (
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/money"
+)
 
 type Options struct {
 	Prec    int
+	PrecSet bool // Prec was set explicitly, so even a Prec of 0 wins over the currency
 	Process int
 	NormUV  bool
+	Curr    money.Currency
+	DivMode dec128.RoundingMode // How quotients are rounded at the default precision, half away from zero by default
+	Exempts Exemptions          // Taxes the customer is exempted from
+	Reverse *ReverseCharge      // Reverse charge of the sale, if the buyer self-assesses its VAT
 
 	DetailTaxProcess DetailTaxProcessor
 }
@@ -30,10 +38,47 @@ func (o *Options) ValueNormalizer(n dec128.Dec128) dec128.Dec128 {
 	return n
 }
 
+// Scale returns the precision of the calculation.
+// When Prec is not set and a currency is known, it defaults to the minor units of the currency.
+// Prec is set when it is not zero or PrecSet is true.
 func (o *Options) Scale() int {
+	if o.Prec == 0 && !o.PrecSet && !o.Curr.IsZero() {
+		return o.Curr.Scale()
+	}
 	return o.Prec
 }
 
+// Currency implements CurrencyInformer.
+func (o *Options) Currency() money.Currency {
+	return o.Curr
+}
+
+// DivisionRounding implements DivisionRounder.
+func (o *Options) DivisionRounding() dec128.RoundingMode {
+	return o.DivMode
+}
+
+// Exemptions implements ExemptionInformer.
+func (o *Options) Exemptions() Exemptions {
+	return o.Exempts
+}
+
+// ReverseCharge implements ReverseChargeInformer.
+func (o *Options) ReverseCharge() *ReverseCharge {
+	return o.Reverse
+}
+
+// WithCurrency implements CurrencyBinder.
+func (o *Options) WithCurrency(c money.Currency) {
+	o.Curr = c
+}
+
+// WithScale sets the precision of the calculation, over the minor units of the currency even when it is 0.
+func (o *Options) WithScale(prec int) {
+	o.Prec = prec
+	o.PrecSet = true
+}
+
 func (o *Options) WithDetailTaxProcessor(dp DetailTaxProcessor) {
 	o.DetailTaxProcess = dp
 }
@@ -47,3 +92,8 @@ func (o *Options) WithUnitValueNormalized() {
 }
 
 var _ CalculationConfiger = &Options{}
+var _ CurrencyInformer = &Options{}
+var _ CurrencyBinder = &Options{}
+var _ DivisionRounder = &Options{}
+var _ ExemptionInformer = &Options{}
+var _ ReverseChargeInformer = &Options{}
@@ -1,26 +1,42 @@
 package withdec128
 
-import "github.com/profe-ajedrez/badassitron/dec128"
+import (
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/money"
+)
 
 type Output struct {
-	UnitValue          dec128.Dec128      // Unitary value
-	Quantity           dec128.Dec128      // Quantity
-	TotalNet           dec128.Dec128      // Net value
-	TotalGross         dec128.Dec128      // Gross value
-	TotalTax           dec128.Dec128      // Tax value
-	TotalDiscount      dec128.Dec128      // Discount value
-	TotalGrossDiscount dec128.Dec128      // Gross discount value
-	DiscontedUnitValue dec128.Dec128      // Discounted unitary value
-	TotalNetWD         dec128.Dec128      // Net with discount value
-	TotalGrossWD       dec128.Dec128      // Gross with discount value
-	TotalTaxWD         dec128.Dec128      // Tax with discount value
-	Taxes              []TaxDetailer      // Detailed taxes
-	Discounts          []DiscountDetailer // Detailed discounts
+	UnitValue           dec128.Dec128            // Unitary value
+	Quantity            dec128.Dec128            // Quantity
+	TotalNet            dec128.Dec128            // Net value
+	TotalGross          dec128.Dec128            // Gross value
+	TotalTax            dec128.Dec128            // Tax value
+	TotalDiscount       dec128.Dec128            // Discount value
+	TotalGrossDiscount  dec128.Dec128            // Gross discount value
+	DiscontedUnitValue  dec128.Dec128            // Discounted unitary value
+	TotalNetWD          dec128.Dec128            // Net with discount value
+	TotalGrossWD        dec128.Dec128            // Gross with discount value
+	TotalTaxWD          dec128.Dec128            // Tax with discount value
+	Taxes               []TaxDetailer            // Detailed taxes
+	Discounts           []DiscountDetailer       // Detailed discounts
+	PayableTotal        dec128.Dec128            // Gross value to pay, after cash rounding
+	CashAdjustment      dec128.Dec128            // Cash rounding adjustment, PayableTotal - TotalGross
+	Pricing             *PricingDetail           // How the unit value was resolved from a price list, if it was
+	Units               *UnitDetail              // Units of measure the line was sold and calculated in, if they differ
+	ExemptBases         map[string]dec128.Dec128 // Base each exempt tax would have applied on, by tax code
+	ZeroRatedBases      map[string]dec128.Dec128 // Base each zero-rated tax would have applied on, by tax code
+	ReverseCharged      bool                     // The buyer self-assesses the reverse charged taxes, left out of TotalTax
+	LegalMention        string                   // Legal mention the invoice must show when ReverseCharged
+	ReverseChargeTax    dec128.Dec128            // Amount of the reverse charged taxes
+	Charges             []ChargeDetailer         // Detailed charges
+	TotalTaxedCharges   dec128.Dec128            // Charges added to the net, the taxes apply on them
+	TotalUntaxedCharges dec128.Dec128            // Charges added to the gross only
+	TotalFreightTax     dec128.Dec128            // Part of TotalTax due to the freight charged on the line
 }
 
 // WithTaxes implements Outputable.
-func (o *Output) WithTaxes([]TaxDetailer) {
-	panic("unimplemented")
+func (o *Output) WithTaxes(taxes []TaxDetailer) {
+	o.Taxes = taxes
 }
 
 func (o *Output) Unitary() dec128.Dec128 {
@@ -111,6 +127,98 @@ func (o *Output) WithTaxWD(taxWD dec128.Dec128) {
 	o.TotalTaxWD = taxWD
 }
 
+// Payable implements CashRoundable.
+func (o *Output) Payable() dec128.Dec128 {
+	return o.PayableTotal
+}
+
+// RoundingAdjustment implements CashRoundable.
+func (o *Output) RoundingAdjustment() dec128.Dec128 {
+	return o.CashAdjustment
+}
+
+// WithPayable implements CashRoundable.
+func (o *Output) WithPayable(payable dec128.Dec128) {
+	o.PayableTotal = payable
+}
+
+// WithRoundingAdjustment implements CashRoundable.
+func (o *Output) WithRoundingAdjustment(adjustment dec128.Dec128) {
+	o.CashAdjustment = adjustment
+}
+
+// WithPricing implements PricingBinder.
+func (o *Output) WithPricing(pd PricingDetail) {
+	o.Pricing = &pd
+}
+
+// LineAmount implements LineAmountInformer, giving the priced amount of the line when it was priced,
+// or the amount in the units it was sold in when they are not the ones it was calculated in.
+func (o *Output) LineAmount() (dec128.Dec128, bool) {
+	switch {
+	case o.Pricing != nil:
+		return o.Pricing.Amount, true
+	case o.Units != nil:
+		return o.Units.SoldUnitValue.Mul(o.Units.SoldQty), true
+	}
+	return Zero(), false
+}
+
+// WithUnits implements UnitBinder.
+func (o *Output) WithUnits(ud UnitDetail) {
+	o.Units = &ud
+}
+
+// ExemptionBases implements ExemptionBinder.
+func (o *Output) ExemptionBases() (exempt, zeroRated map[string]dec128.Dec128) {
+	return o.ExemptBases, o.ZeroRatedBases
+}
+
+// WithExemptBases implements ExemptionBinder.
+func (o *Output) WithExemptBases(exempt, zeroRated map[string]dec128.Dec128) {
+	o.ExemptBases = exempt
+	o.ZeroRatedBases = zeroRated
+}
+
+// ReverseCharge implements ReverseChargeBinder.
+func (o *Output) ReverseCharge() (charged bool, mention string, tax dec128.Dec128) {
+	return o.ReverseCharged, o.LegalMention, o.ReverseChargeTax
+}
+
+// WithReverseCharge implements ReverseChargeBinder.
+func (o *Output) WithReverseCharge(mention string, tax dec128.Dec128) {
+	o.ReverseCharged = true
+	o.LegalMention = mention
+	o.ReverseChargeTax = tax
+}
+
+// DetailCharges implements ChargeBinder.
+func (o *Output) DetailCharges() []ChargeDetailer {
+	return o.Charges
+}
+
+// UntaxedCharges implements ChargeBinder.
+func (o *Output) UntaxedCharges() dec128.Dec128 {
+	return o.TotalUntaxedCharges
+}
+
+// WithCharges implements ChargeBinder.
+func (o *Output) WithCharges(details []ChargeDetailer, taxed, untaxed dec128.Dec128) {
+	o.Charges = details
+	o.TotalTaxedCharges = taxed
+	o.TotalUntaxedCharges = untaxed
+}
+
+// FreightTax implements FreightBinder.
+func (o *Output) FreightTax() dec128.Dec128 {
+	return o.TotalFreightTax
+}
+
+// WithFreightTax implements FreightBinder.
+func (o *Output) WithFreightTax(tax dec128.Dec128) {
+	o.TotalFreightTax = tax
+}
+
 // DetailTaxes returns the detailed taxes.
 func (o *Output) DetailTaxes() []TaxDetailer {
 	return o.Taxes
@@ -121,3 +229,43 @@ func (o *Output) DetailDiscount() []DiscountDetailer {
 }
 
 var _ Outputable = (*Output)(nil)
+var _ CashRoundable = (*Output)(nil)
+var _ PricingBinder = (*Output)(nil)
+var _ LineAmountInformer = (*Output)(nil)
+var _ UnitBinder = (*Output)(nil)
+var _ ExemptionBinder = (*Output)(nil)
+var _ ReverseChargeBinder = (*Output)(nil)
+var _ ChargeBinder = (*Output)(nil)
+var _ FreightBinder = (*Output)(nil)
+
+// CurrencyOutput is an Output whose amounts are expressed in Curr.
+type CurrencyOutput struct {
+	Output
+	Curr      money.Currency   // Currency of the amounts
+	Reporting *ReportingOutput // Output converted into the reporting currency, if any
+}
+
+// Currency implements CurrencyInformer.
+func (co *CurrencyOutput) Currency() money.Currency {
+	return co.Curr
+}
+
+// WithCurrency implements CurrencyBinder.
+func (co *CurrencyOutput) WithCurrency(c money.Currency) {
+	co.Curr = c
+}
+
+// Money returns v as an amount in the currency of the output.
+func (co *CurrencyOutput) Money(v dec128.Dec128) money.Money {
+	return money.NewFromCurrency(v, co.Curr)
+}
+
+// WithReporting implements ReportingBinder.
+func (co *CurrencyOutput) WithReporting(ro *ReportingOutput) {
+	co.Reporting = ro
+}
+
+var _ Outputable = (*CurrencyOutput)(nil)
+var _ CurrencyInformer = (*CurrencyOutput)(nil)
+var _ CurrencyBinder = (*CurrencyOutput)(nil)
+var _ ReportingBinder = (*CurrencyOutput)(nil)
@@ -0,0 +1,136 @@
+package withdec128
+
+import (
+	"github.com/profe-ajedrez/badassitron/dec128"
+)
+
+// PricingMode defines how the tiers of a price list apply to a quantity.
+type PricingMode int8
+
+const (
+	PricingAllUnits  PricingMode = 0 // Every unit at the price of the tier the quantity falls in
+	PricingGraduated PricingMode = 1 // Each unit at the price of the tier it falls in
+)
+
+// PriceTier is the unit value of a product for the quantities up to UpTo.
+// A tier starts right after the UpTo of the previous one, the first one starts at zero.
+type PriceTier struct {
+	UpTo  dec128.Dec128 // Largest quantity of the tier. Zero in the last tier means no limit
+	Price dec128.Dec128 // Unit value of the tier
+}
+
+// PriceList holds the tiers of the prices of products, keyed by product.
+//
+// For example, with the tiers up to 10 at 5.00 and over 10 at 4.50, 15 units cost 15 * 4.50 = 67.50
+// in PricingAllUnits mode, and 10 * 5.00 + 5 * 4.50 = 72.50 in PricingGraduated mode.
+type PriceList struct {
+	Name   string                 // Identifies the list in the pricing detail
+	Mode   PricingMode            // How the tiers apply
+	Prices map[string][]PriceTier // Tiers of each product, in increasing UpTo order
+}
+
+// TierUsage is the part of a quantity priced at a tier.
+type TierUsage struct {
+	Tier   int           // Index of the tier
+	Qty    dec128.Dec128 // Quantity priced at the tier
+	Price  dec128.Dec128 // Unit value of the tier
+	Amount dec128.Dec128 // Qty * Price
+}
+
+// PricingDetail tells how the unit value of a line was resolved from a price list.
+type PricingDetail struct {
+	Source    string        // Name of the price list the unit value comes from
+	Product   string        // Product looked up
+	Mode      PricingMode   // How the tiers were applied
+	Tier      int           // Index of the tier the whole quantity falls in
+	Usage     []TierUsage   // Parts of the quantity priced at each tier, just one in PricingAllUnits mode
+	Amount    dec128.Dec128 // Sum of the amounts of the tiers used
+	UnitValue dec128.Dec128 // Resolved unit value, Amount / quantity
+}
+
+// Has tells whether the list has prices for product.
+func (pl PriceList) Has(product string) bool {
+	_, ok := pl.Prices[product]
+	return ok
+}
+
+// Price returns how qty units of product are priced by the list. In PricingGraduated mode, the unit value is
+// the average of the tiers used, rounded at the default precision using mode.
+func (pl PriceList) Price(product string, qty dec128.Dec128, mode dec128.RoundingMode) (PricingDetail, error) {
+	tiers, ok := pl.Prices[product]
+	if !ok || len(tiers) == 0 {
+		return PricingDetail{}, ErrProductNotPriced
+	}
+
+	if err := validateTiers(tiers); err != nil {
+		return PricingDetail{}, err
+	}
+
+	tier := -1
+	for i, t := range tiers {
+		if t.UpTo.IsZero() || qty.LessThanOrEqual(t.UpTo) {
+			tier = i
+			break
+		}
+	}
+
+	if tier < 0 {
+		return PricingDetail{}, ErrQtyOverTiers
+	}
+
+	pd := PricingDetail{Source: pl.Name, Product: product, Mode: pl.Mode, Tier: tier}
+
+	if pl.Mode == PricingGraduated {
+		from := Zero()
+		for i, t := range tiers[:tier+1] {
+			upTo := qty
+			if i < tier {
+				upTo = t.UpTo
+			}
+			pd.Usage = append(pd.Usage, newTierUsage(i, upTo.Sub(from), t.Price))
+			from = t.UpTo
+		}
+	} else {
+		pd.Usage = []TierUsage{newTierUsage(tier, qty, tiers[tier].Price)}
+	}
+
+	pd.Amount = Zero()
+	for _, u := range pd.Usage {
+		pd.Amount = pd.Amount.Add(u.Amount)
+	}
+
+	if pl.Mode == PricingGraduated {
+		pd.UnitValue, _ = pd.Amount.DivRound(qty, dec128.DefaultPrecision(), mode)
+	} else {
+		pd.UnitValue = tiers[tier].Price
+	}
+
+	return pd, nil
+}
+
+func newTierUsage(tier int, qty, price dec128.Dec128) TierUsage {
+	return TierUsage{Tier: tier, Qty: qty, Price: price, Amount: qty.Mul(price)}
+}
+
+// validateTiers checks that prices are not negative and that tiers are in increasing UpTo order, with only
+// the last one unlimited.
+func validateTiers(tiers []PriceTier) error {
+	for i, t := range tiers {
+		if t.Price.IsNegative() {
+			return ErrNegativeUnitary
+		}
+
+		if t.UpTo.IsZero() {
+			if i != len(tiers)-1 {
+				return ErrInvalidTiers
+			}
+			continue
+		}
+
+		if t.UpTo.IsNegative() || (i > 0 && t.UpTo.LessThanOrEqual(tiers[i-1].UpTo)) {
+			return ErrInvalidTiers
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package withdec128
+
+import (
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/money"
+)
+
+// ReportingOutput is an Output converted into a reporting currency.
+type ReportingOutput struct {
+	Output
+	Curr money.Currency     // Reporting currency
+	Rate money.ExchangeRate // Exchange rate used in the conversion
+}
+
+// Currency implements CurrencyInformer.
+func (ro *ReportingOutput) Currency() money.Currency {
+	return ro.Curr
+}
+
+// ExchangeRate returns the exchange rate used in the conversion.
+func (ro *ReportingOutput) ExchangeRate() money.ExchangeRate {
+	return ro.Rate
+}
+
+// ConvertOutput converts the amounts of out, tax, discount and charge details, exempt bases and reverse charged taxes included,
+// into the currency to using rate. Whether each tax hit its cap is kept as it was, and the parts of the base taxed
+// at each bracket are converted too.
+// Every converted amount is rounded to the minor units of to. Gross values are recalculated
+// from the rounded net and tax values, so the converted output still adds up.
+func ConvertOutput(out Outputable, rate money.ExchangeRate, to money.Currency) *ReportingOutput {
+	conv := func(v dec128.Dec128) dec128.Dec128 {
+		return v.Mul(rate.Rate).RoundHalfAwayFromZero(to.MinorUnits)
+	}
+
+	ro := &ReportingOutput{Curr: to, Rate: rate}
+
+	ro.WithUnitary(conv(out.Unitary()))
+	ro.WithQty(out.Qty())
+	ro.WithDiscontedUnitary(conv(out.DiscontedUnitary()))
+	ro.WithNet(conv(out.Net()))
+	ro.WithNetWD(conv(out.NetWD()))
+	ro.WithTax(conv(out.Tax()))
+	ro.WithTaxWD(conv(out.TaxWD()))
+	ro.WithDiscount(conv(out.Discount()))
+
+	if cb, ok := out.(ChargeBinder); ok && cb.DetailCharges() != nil {
+		charges := make([]ChargeDetailer, len(cb.DetailCharges()))
+		taxed, untaxed := Zero(), Zero()
+		for i, c := range cb.DetailCharges() {
+			charges[i] = &DetailCharge{
+				code:    c.Code(),
+				name:    c.Name(),
+				percent: c.Percent(),
+				base:    conv(c.Base()),
+				amount:  conv(c.Amount()),
+				taxable: c.Taxable(),
+				freight: c.Freight(),
+			}
+			if c.Taxable() {
+				taxed = taxed.Add(charges[i].Amount())
+			} else {
+				untaxed = untaxed.Add(charges[i].Amount())
+			}
+		}
+		ro.WithCharges(charges, taxed, untaxed)
+	}
+
+	if fb, ok := out.(FreightBinder); ok {
+		ro.WithFreightTax(conv(fb.FreightTax()))
+	}
+
+	if eb, ok := out.(ExemptionBinder); ok {
+		if exempt, zeroRated := eb.ExemptionBases(); exempt != nil || zeroRated != nil {
+			ro.WithExemptBases(convBases(exempt, conv), convBases(zeroRated, conv))
+		}
+	}
+
+	if rb, ok := out.(ReverseChargeBinder); ok {
+		if charged, mention, tax := rb.ReverseCharge(); charged {
+			ro.WithReverseCharge(mention, conv(tax))
+		}
+	}
+
+	ro.WithGross(ro.Net().Add(ro.Tax()).Add(ro.UntaxedCharges()))
+	ro.WithGrossWD(ro.NetWD().Add(ro.TaxWD()).Add(ro.UntaxedCharges()))
+	ro.WithGrossDiscount(ro.GrossWD().Sub(ro.Gross()))
+
+	if taxes := out.DetailTaxes(); taxes != nil {
+		converted := make([]TaxDetailer, len(taxes))
+		for i, tax := range taxes {
+			d := &DetailTax{
+				code:      tax.Code(),
+				name:      tax.Name(),
+				taxable:   conv(tax.Taxable()),
+				rawAmount: conv(tax.RawAmount()),
+				percent:   tax.Percent(),
+				amount:    conv(tax.Amount()),
+				id:        tax.ID(),
+				typee:     tax.Type(),
+			}
+			if ei, ok := tax.(ExemptTaxInformer); ok {
+				d.exemption = ei.Exemption()
+			}
+			if rd, ok := tax.(ReverseChargeDetailer); ok {
+				d.reverse = rd.ReverseCharged()
+			}
+			if cd, ok := tax.(CapDetailer); ok {
+				d.capHit = cd.CapHit()
+			}
+			if bd, ok := tax.(BracketDetailer); ok && bd.Brackets() != nil {
+				d.brackets = make([]BracketUsage, len(bd.Brackets()))
+				for j, u := range bd.Brackets() {
+					d.brackets[j] = BracketUsage{Bracket: u.Bracket, Base: conv(u.Base), Rate: u.Rate, Amount: conv(u.Amount)}
+				}
+			}
+			converted[i] = d
+		}
+		ro.WithTaxes(converted)
+	}
+
+	if discounts := out.DetailDiscount(); discounts != nil {
+		ro.Discounts = make([]DiscountDetailer, len(discounts))
+		for i, disc := range discounts {
+			ro.Discounts[i] = &DetailDiscount{
+				percent:    disc.Percent(),
+				amount:     conv(disc.Amount()),
+				rawPercent: disc.RawPercent(),
+				net:        conv(disc.Net()),
+			}
+		}
+	}
+
+	return ro
+}
+
+// convBases converts the bases by tax code using conv.
+func convBases(bases map[string]dec128.Dec128, conv func(dec128.Dec128) dec128.Dec128) map[string]dec128.Dec128 {
+	if bases == nil {
+		return nil
+	}
+
+	converted := make(map[string]dec128.Dec128, len(bases))
+	for code, base := range bases {
+		converted[code] = conv(base)
+	}
+	return converted
+}
+
+var _ Outputable = (*ReportingOutput)(nil)
+var _ CurrencyInformer = (*ReportingOutput)(nil)
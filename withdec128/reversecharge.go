@@ -0,0 +1,31 @@
+package withdec128
+
+import (
+	"strings"
+)
+
+// ReverseCharge is the mode in which the buyer self-assesses the VAT of the sale instead of the seller charging it,
+// as for intra-EU B2B services. The reverse charged taxes are detailed as usual but left out of the tax and gross of the output.
+type ReverseCharge struct {
+	TaxCodes []string // Codes of the percentual taxes the buyer self-assesses, every percentual tax when empty
+	Mention  string   // Legal mention the invoice must show, e.g. "Art. 196 Directive 2006/112/EC"
+}
+
+// Applies tells if tx is reverse charged. Only percentual taxes are.
+func (rc *ReverseCharge) Applies(tx TaxInformer) bool {
+	if rc == nil || tx == nil || tx.Type() != Percentual {
+		return false
+	}
+
+	if len(rc.TaxCodes) == 0 {
+		return true
+	}
+
+	for _, code := range rc.TaxCodes {
+		if strings.EqualFold(code, tx.Code()) {
+			return true
+		}
+	}
+
+	return false
+}
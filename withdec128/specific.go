@@ -0,0 +1,98 @@
+package withdec128
+
+import (
+	"github.com/profe-ajedrez/badassitron/dec128"
+)
+
+// Attributes are the physical attributes of one unit of the quantity of a line, e.g. "volume", "degree" or "sugar",
+// used to compute Specific taxes.
+type Attributes map[string]dec128.Dec128
+
+// SpecificFormula returns the amount of a Specific tax for one unit, given the value of the tax and the attributes of the line.
+type SpecificFormula func(value dec128.Dec128, attrs Attributes) (dec128.Dec128, error)
+
+// PerAttribute returns a formula charging the value of the tax for each unit of the product of the attributes names.
+//
+// For example, PerAttribute("volume") charges a fuel excise per liter, and PerAttribute("volume", "degree")
+// an alcohol excise per liter and degree of alcohol.
+func PerAttribute(names ...string) SpecificFormula {
+	return func(value dec128.Dec128, attrs Attributes) (dec128.Dec128, error) {
+		amount := value
+		for _, name := range names {
+			v, err := attrs.get(name)
+			if err != nil {
+				return dec128.Dec128{}, err
+			}
+			amount = amount.Mul(v)
+		}
+		return amount, nil
+	}
+}
+
+// PerAttributeOver returns a formula charging the value of the tax for each unit of the attribute name over threshold,
+// and nothing when it does not exceed it. For example, PerAttributeOver("sugar", 6.25) charges a sugar tax
+// per gram over 6.25 grams.
+func PerAttributeOver(name string, threshold dec128.Dec128) SpecificFormula {
+	return func(value dec128.Dec128, attrs Attributes) (dec128.Dec128, error) {
+		v, err := attrs.get(name)
+		if err != nil {
+			return dec128.Dec128{}, err
+		}
+
+		if v.LessThanOrEqual(threshold) {
+			return Zero(), nil
+		}
+
+		return value.Mul(v.Sub(threshold)), nil
+	}
+}
+
+func (a Attributes) get(name string) (dec128.Dec128, error) {
+	v, ok := a[name]
+	if !ok {
+		return dec128.Dec128{}, NewTaxError(ErrMissingAttribute, name)
+	}
+
+	if v.IsNaN() || v.IsNegative() {
+		return dec128.Dec128{}, NewTaxError(ErrInvalidAttribute, name)
+	}
+
+	return v, nil
+}
+
+// ResolveSpecificTax returns tx with its value replaced by the amount per unit its formula gives for attrs,
+// when tx is a Specific tax. Other taxes are returned as they are.
+// A Specific tax must implement SpecificTaxInformer.
+func ResolveSpecificTax(tx TaxInformer, attrs Attributes) (TaxInformer, error) {
+	if tx == nil || tx.Type() != Specific {
+		return tx, nil
+	}
+
+	st, ok := tx.(SpecificTaxInformer)
+	if !ok || st.SpecificFormula() == nil {
+		return nil, NewTaxError(ErrNoFormula, tx.String())
+	}
+
+	amount, err := st.SpecificFormula()(tx.Value(), attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &specificTax{TaxInformer: tx, amount: amount}, nil
+}
+
+// specificTax is a Specific tax with the amount per unit its formula gave.
+type specificTax struct {
+	TaxInformer
+	amount dec128.Dec128
+}
+
+// Value implements TaxInformer.
+func (st *specificTax) Value() dec128.Dec128 {
+	return st.amount
+}
+
+// TaxCap implements TaxCapper, with the cap of the Specific tax.
+func (st *specificTax) TaxCap() *TaxCap {
+	return capOf(st.TaxInformer)
+}
@@ -92,8 +92,31 @@ func (n *BypassTaxStage) Validate(tx TaxInformer) error {
 }
 
 type TaxStage struct {
-	amount  dec128.Dec128
-	percent dec128.Dec128
+	amount    dec128.Dec128
+	percent   dec128.Dec128
+	percentWD dec128.Dec128 // Percentual taxes applying on the net before discounts
+	single    []singleTax   // Capped percentual and bracketed taxes, calculated one by one
+}
+
+// singleTax is a tax of a stage calculated on its own, because it is bracketed or its amount is bounded by a cap.
+type singleTax struct {
+	percent      dec128.Dec128
+	brackets     *TaxBrackets // Brackets of a Bracketed tax, nil for a percentual one
+	undiscounted bool         // Applies on the net before discounts
+	cap          *TaxCap
+}
+
+// calc returns the amount of the tax on base, bounded by its cap.
+func (st singleTax) calc(base, qty dec128.Dec128) dec128.Dec128 {
+	var amount dec128.Dec128
+	if st.brackets != nil {
+		amount, _ = st.brackets.Calc(base)
+	} else {
+		amount = base.Mul(st.percent.Div(dec128.Decimal100))
+	}
+
+	amount, _ = st.cap.Apply(amount, qty)
+	return amount
 }
 
 func (n *TaxStage) Validate(tx TaxInformer) error {
@@ -105,7 +128,7 @@ func (n *TaxStage) Validate(tx TaxInformer) error {
 		return ErrNegativeTax
 	}
 
-	if tx.Type() != Percentual && tx.Type() != Amount && tx.Type() != AmountLine {
+	if tx.Type() != Percentual && tx.Type() != Amount && tx.Type() != AmountLine && tx.Type() != Specific && tx.Type() != Bracketed {
 		return ErrInvalidTaxType
 	}
 
@@ -117,15 +140,41 @@ func (n *TaxStage) Validate(tx TaxInformer) error {
 		return ErrTaxOver100
 	}
 
+	if tx.Type() == Bracketed {
+		if err := bracketsOf(tx).Validate(); err != nil {
+			return NewTaxError(err, tx.String())
+		}
+	}
+
+	if err := capOf(tx).Validate(); err != nil {
+		return err
+	}
+
+	if base, value := taxBaseOf(tx); base < BaseNet || base > BaseFixed || value.IsNaN() || value.IsNegative() {
+		return ErrInvalidTaxBase
+	}
+
 	return nil
 }
 
 func (t *TaxStage) Bind(qty dec128.Dec128, tx TaxInformer) {
+	if c := capOf(tx); c != nil || tx.Type() == Bracketed {
+		t.bindSingle(qty, tx, c)
+		return
+	}
+
 	if tx.Type() == Percentual {
-		t.percent = t.percent.Add(tx.Value())
+		switch base, value := taxBaseOf(tx); base {
+		case BaseNetWD:
+			t.percentWD = t.percentWD.Add(tx.Value())
+		case BaseReference, BaseFixed:
+			t.amount = t.amount.Add(fixedBase(base, value, qty).Mul(tx.Value().Div(dec128.Decimal100)))
+		default:
+			t.percent = t.percent.Add(tx.Value())
+		}
 	}
 
-	if tx.Type() == Amount {
+	if tx.Type() == Amount || tx.Type() == Specific {
 		t.amount = t.amount.Add(tx.Value().Mul(qty))
 	}
 
@@ -134,30 +183,113 @@ func (t *TaxStage) Bind(qty dec128.Dec128, tx TaxInformer) {
 	}
 }
 
+// bindSingle binds tx, bounded by c, to be calculated on its own. Its amount is known at once unless
+// it is a percentual or bracketed tax on the net.
+func (t *TaxStage) bindSingle(qty dec128.Dec128, tx TaxInformer, c *TaxCap) {
+	var amount dec128.Dec128
+
+	switch tx.Type() {
+	case Percentual, Bracketed:
+		st := singleTax{percent: tx.Value(), cap: c}
+		if tx.Type() == Bracketed {
+			st.brackets = bracketsOf(tx)
+		}
+
+		base, value := taxBaseOf(tx)
+		if base == BaseNet || base == BaseNetWD {
+			st.undiscounted = base == BaseNetWD
+			t.single = append(t.single, st)
+			return
+		}
+
+		t.amount = t.amount.Add(st.calc(fixedBase(base, value, qty), qty))
+		return
+	case Amount, Specific:
+		amount = tx.Value().Mul(qty)
+	case AmountLine:
+		amount = tx.Value()
+	}
+
+	amount, _ = c.Apply(amount, qty)
+	t.amount = t.amount.Add(amount)
+}
+
 func (t *TaxStage) Calc(taxable, qty dec128.Dec128) dec128.Dec128 {
+	return t.CalcOn(taxable, taxable, qty)
+}
+
+// CalcOn is like Calc, but the taxes declaring BaseNetWD apply on undiscounted instead of taxable.
+func (t *TaxStage) CalcOn(taxable, undiscounted, qty dec128.Dec128) dec128.Dec128 {
 	r := t.percent.Div(dec128.Decimal100)
-	return taxable.Mul(r).Add(t.amount)
+	total := taxable.Mul(r).Add(t.amount)
+
+	if !t.percentWD.IsZero() {
+		total = total.Add(undiscounted.Mul(t.percentWD.Div(dec128.Decimal100)))
+	}
+
+	for _, st := range t.single {
+		base := taxable
+		if st.undiscounted {
+			base = undiscounted
+		}
+
+		total = total.Add(st.calc(base, qty))
+	}
+
+	return total
+}
+
+// taxBaseOf returns the base tx applies on and the value of the base. Taxes not implementing TaxBaser apply on the net.
+func taxBaseOf(tx TaxInformer) (TaxBase, dec128.Dec128) {
+	if tb, ok := tx.(TaxBaser); ok {
+		return tb.Base(), tb.BaseValue()
+	}
+	return BaseNet, Zero()
+}
+
+// fixedBase returns the line amount a tax with a BaseReference or BaseFixed base applies on.
+func fixedBase(base TaxBase, value, qty dec128.Dec128) dec128.Dec128 {
+	if base == BaseReference {
+		return value.Mul(qty)
+	}
+	return value
 }
 
 type DetailTaxes struct {
-	list map[int]TaxDetailer
+	list     map[int]TaxDetailer
+	order    []int                 // IDs in the order the taxes were bound
+	stages   map[int]Stage         // Stage of each tax, by ID
+	bases    map[int]TaxBase       // Base of each percentual or bracketed tax, by ID
+	fixed    map[int]dec128.Dec128 // Line amount of the taxes with a BaseReference or BaseFixed base, by ID
+	caps     map[int]*TaxCap       // Cap of each capped tax, by ID
+	brackets map[int]*TaxBrackets  // Brackets of each bracketed tax, by ID
 }
 
+// DetailTaxes returns the detailed taxes in the order they were bound.
 func (dt *DetailTaxes) DetailTaxes() []TaxDetailer {
-	details := make([]TaxDetailer, 0, len(dt.list))
-	for _, tax := range dt.list {
-		details = append(details, tax)
+	details := make([]TaxDetailer, 0, len(dt.order))
+	for _, id := range dt.order {
+		details = append(details, dt.list[id])
 	}
 	return details
 }
 
 func NewDetailTaxes() *DetailTaxes {
 	return &DetailTaxes{
-		list: make(map[int]TaxDetailer),
+		list:     make(map[int]TaxDetailer),
+		stages:   make(map[int]Stage),
+		bases:    make(map[int]TaxBase),
+		fixed:    make(map[int]dec128.Dec128),
+		caps:     make(map[int]*TaxCap),
+		brackets: make(map[int]*TaxBrackets),
 	}
 }
 
 func (dt *DetailTaxes) Bind(qty dec128.Dec128, tx TaxInformer) {
+	if _, ok := dt.list[tx.ID()]; !ok {
+		dt.order = append(dt.order, tx.ID())
+	}
+	dt.stages[tx.ID()] = tx.Stage()
 
 	dt.list[tx.ID()] = &DetailTax{
 		code:      tx.Code(),
@@ -173,26 +305,191 @@ func (dt *DetailTaxes) Bind(qty dec128.Dec128, tx TaxInformer) {
 		dt.list[tx.ID()].WithPercent(tx.Value())
 	}
 
-	if tx.Type() == Amount {
+	if tx.Type() == Percentual || tx.Type() == Bracketed {
+		base, value := taxBaseOf(tx)
+		dt.bases[tx.ID()] = base
+		dt.fixed[tx.ID()] = fixedBase(base, value, qty)
+	}
+
+	if tx.Type() == Bracketed {
+		dt.brackets[tx.ID()] = bracketsOf(tx)
+	}
+
+	if tx.Type() == Amount || tx.Type() == Specific {
 		dt.list[tx.ID()].WithAmount(tx.Value().Mul(qty))
 	}
 
 	if tx.Type() == AmountLine {
 		dt.list[tx.ID()].WithAmount(tx.Value())
 	}
+
+	if c := capOf(tx); c != nil {
+		dt.caps[tx.ID()] = c
+		if tx.Type() != Percentual && tx.Type() != Bracketed {
+			dt.applyCap(tx.ID(), qty)
+		}
+	}
+
+	if et, ok := tx.(ExemptTaxInformer); ok {
+		if ed, ok := dt.list[tx.ID()].(ExemptionDetailer); ok {
+			ed.WithExemption(et.Exemption())
+		}
+	}
 }
 
+// Reverse marks the bound tax id as reverse charged. The buyer self-assesses it, so when natural it is left out
+// of the base of the overtaxes, like it is left out of the totals.
+func (dt *DetailTaxes) Reverse(id int) {
+	if rd, ok := dt.list[id].(ReverseChargeDetailer); ok {
+		rd.WithReverseCharged(true)
+	}
+}
+
+// reversed tells whether the tax id was marked reverse charged.
+func (dt *DetailTaxes) reversed(id int) bool {
+	rd, ok := dt.list[id].(ReverseChargeDetailer)
+	return ok && rd.ReverseCharged()
+}
+
+// Calc computes the amounts of the percentual taxes and the ratios of the rest. Overtaxes apply on
+// the taxable value plus the natural taxes, like the totals of the calculation do.
 func (dt *DetailTaxes) Calc(taxableToInform, taxableToCalculate, qty dec128.Dec128) {
-	for _, tax := range dt.list {
+	net := taxableToCalculate.Mul(qty)
+	dt.CalcOn(taxableToInform, net, net, qty)
+}
+
+// CalcOn is like Calc, but on the net of the line instead of a unit value, so the detail matches the totals when
+// the net is not the unit value times the quantity. The taxes declaring BaseNetWD apply on netWD, the net before discounts.
+// Taxes declaring another base than the net inform that base as their taxable.
+func (dt *DetailTaxes) CalcOn(taxableToInform, net, netWD, qty dec128.Dec128) {
+	natural := Zero()
+	for _, id := range dt.order {
+		if dt.stages[id] == Overtax {
+			continue
+		}
+
+		tax := dt.list[id]
+		dt.calc(tax, net, qty)
+		tax.WithTaxable(taxableToInform)
+		dt.calcOnBase(id, netWD, Zero())
+		dt.calcBracketed(id, net, netWD, Zero())
+		if tax.Type() == Percentual || tax.Type() == Bracketed {
+			dt.applyCap(id, qty)
+		}
+
+		if dt.stages[id] == Natural && !dt.reversed(id) {
+			natural = natural.Add(tax.Amount())
+		}
+	}
+
+	base := net.Add(natural)
+	for _, id := range dt.order {
+		if dt.stages[id] != Overtax {
+			continue
+		}
+
+		tax := dt.list[id]
 		if tax.Type() == Percentual {
-			porcentualAmount := taxableToCalculate.Mul(tax.Percent().Div(dec128.Decimal100)).Mul(qty)
+			porcentualAmount := base.Mul(tax.Percent().Div(dec128.Decimal100))
 			tax.WithRawAmount(porcentualAmount)
 			tax.WithAmount(porcentualAmount)
-		} else if tax.Type() == Amount {
-			ratio := tax.Amount().Mul(dec128.Decimal100).Div(taxableToCalculate)
-			tax.WithPercent(ratio)
+		} else {
+			dt.calc(tax, net, qty)
 		}
 		tax.WithTaxable(taxableToInform)
+		dt.calcOnBase(id, netWD, natural)
+		dt.calcBracketed(id, net, netWD, natural)
+		if tax.Type() == Percentual || tax.Type() == Bracketed {
+			dt.applyCap(id, qty)
+		}
+	}
+}
+
+// calcBracketed computes the amount of the bracketed tax id, on the net of the line or the base it declares,
+// plus natural when it is an overtax, and keeps the part of the base taxed at each bracket. Its percent is the
+// effective rate on that base.
+func (dt *DetailTaxes) calcBracketed(id int, taxable, undiscounted, natural dec128.Dec128) {
+	tb, ok := dt.brackets[id]
+	if !ok {
+		return
+	}
+
+	base := taxable.Add(natural)
+	switch dt.bases[id] {
+	case BaseNetWD:
+		base = undiscounted.Add(natural)
+		dt.list[id].WithTaxable(undiscounted)
+	case BaseReference, BaseFixed:
+		base = dt.fixed[id]
+		dt.list[id].WithTaxable(base)
+	}
+
+	amount, usage := tb.Calc(base)
+
+	tax := dt.list[id]
+	tax.WithRawAmount(amount)
+	tax.WithAmount(amount)
+	if !base.IsZero() {
+		tax.WithPercent(amount.Mul(dec128.Decimal100).Div(base))
+	}
+	if bd, ok := tax.(BracketDetailer); ok {
+		bd.WithBrackets(usage)
+	}
+}
+
+// applyCap bounds the amount of the tax id by its cap, if it has one, keeping the amount before it as the raw amount.
+func (dt *DetailTaxes) applyCap(id int, qty dec128.Dec128) {
+	c, ok := dt.caps[id]
+	if !ok {
+		return
+	}
+
+	tax := dt.list[id]
+	raw := tax.Amount()
+	amount, hit := c.Apply(raw, qty)
+
+	tax.WithRawAmount(raw)
+	tax.WithAmount(amount)
+	if cd, ok := tax.(CapDetailer); ok {
+		cd.WithCapHit(hit)
+	}
+}
+
+// calcOnBase recomputes the amount of the percentual tax id when it declares another base than the net.
+// undiscounted is the net of the line before discounts, and natural the natural taxes an overtax on it adds to it.
+func (dt *DetailTaxes) calcOnBase(id int, undiscounted, natural dec128.Dec128) {
+	if dt.list[id].Type() != Percentual {
+		return
+	}
+
+	var taxable, base dec128.Dec128
+
+	switch dt.bases[id] {
+	case BaseNetWD:
+		taxable, base = undiscounted, undiscounted.Add(natural)
+	case BaseReference, BaseFixed:
+		taxable, base = dt.fixed[id], dt.fixed[id]
+	default:
+		return
+	}
+
+	tax := dt.list[id]
+	porcentualAmount := base.Mul(tax.Percent().Div(dec128.Decimal100))
+	tax.WithRawAmount(porcentualAmount)
+	tax.WithAmount(porcentualAmount)
+	tax.WithTaxable(taxable)
+}
+
+// calc computes the amount of the percentual tax on net, the net of the line, or the ratio of the amount of the rest
+// to the net of a unit.
+func (dt *DetailTaxes) calc(tax TaxDetailer, net, qty dec128.Dec128) {
+	if tax.Type() == Percentual {
+		porcentualAmount := net.Mul(tax.Percent().Div(dec128.Decimal100))
+		tax.WithRawAmount(porcentualAmount)
+		tax.WithAmount(porcentualAmount)
+	} else if tax.Type() == Amount || tax.Type() == Specific {
+		ratio := tax.Amount().Mul(qty).Mul(dec128.Decimal100).Div(net)
+		tax.WithPercent(ratio)
 	}
 }
 
@@ -205,6 +502,10 @@ type DetailTax struct {
 	amount    dec128.Dec128
 	id        int
 	typee     Type
+	exemption *Exemption     // Why the tax was not charged, if it was not
+	reverse   bool           // The buyer self-assesses the tax
+	capHit    CapHit         // Whether the amount was bounded by the cap of the tax
+	brackets  []BracketUsage // Parts of the base taxed at each bracket, for a bracketed tax
 }
 
 func (dt *DetailTax) Code() string {
@@ -271,5 +572,49 @@ func (dt *DetailTax) WithType(tp Type) {
 	dt.typee = tp
 }
 
+// Exemption implements ExemptTaxInformer.
+func (dt *DetailTax) Exemption() *Exemption {
+	return dt.exemption
+}
+
+// WithExemption implements ExemptionDetailer.
+func (dt *DetailTax) WithExemption(ex *Exemption) {
+	dt.exemption = ex
+}
+
+// ReverseCharged implements ReverseChargeDetailer.
+func (dt *DetailTax) ReverseCharged() bool {
+	return dt.reverse
+}
+
+// WithReverseCharged implements ReverseChargeDetailer.
+func (dt *DetailTax) WithReverseCharged(rc bool) {
+	dt.reverse = rc
+}
+
+// CapHit implements CapDetailer.
+func (dt *DetailTax) CapHit() CapHit {
+	return dt.capHit
+}
+
+// WithCapHit implements CapDetailer.
+func (dt *DetailTax) WithCapHit(hit CapHit) {
+	dt.capHit = hit
+}
+
+// Brackets implements BracketDetailer.
+func (dt *DetailTax) Brackets() []BracketUsage {
+	return dt.brackets
+}
+
+// WithBrackets implements BracketDetailer.
+func (dt *DetailTax) WithBrackets(usage []BracketUsage) {
+	dt.brackets = usage
+}
+
 var _ TaxDetailer = &DetailTax{}
+var _ ExemptionDetailer = &DetailTax{}
+var _ ReverseChargeDetailer = &DetailTax{}
+var _ CapDetailer = &DetailTax{}
+var _ BracketDetailer = &DetailTax{}
 var _ DetailTaxProcessor = &DetailTaxes{}
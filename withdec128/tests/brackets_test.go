@@ -0,0 +1,189 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/money"
+	"github.com/profe-ajedrez/badassitron/withdec128"
+	"github.com/profe-ajedrez/badassitron/withdec128/handler"
+)
+
+func taxBrackets(mode withdec128.BracketMode, brackets ...string) *withdec128.TaxBrackets {
+	tb := &withdec128.TaxBrackets{Mode: mode}
+	for i := 0; i < len(brackets); i += 2 {
+		tb.Brackets = append(tb.Brackets, withdec128.TaxBracket{UpTo: dec128.FromString(brackets[i]), Rate: dec128.FromString(brackets[i+1])})
+	}
+	return tb
+}
+
+func TestBracketedTaxes(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	marginal := taxBrackets(withdec128.BracketsMarginal, "1000", "0", "1500", "10", "0", "20")
+	wholeBase := taxBrackets(withdec128.BracketsWholeBase, "1000", "0", "1500", "10", "0", "20")
+
+	lux := func(stage withdec128.Stage, tb *withdec128.TaxBrackets) *withdec128.InputTax {
+		return &withdec128.InputTax{CodeValue: "LUX", Id: 1, Typee: withdec128.Bracketed, Stagee: stage, Brackets: tb}
+	}
+
+	type testCase struct {
+		name    string
+		taxes   []*withdec128.InputTax
+		exempts withdec128.Exemptions
+		tax     string
+		lux     string
+		percent string
+		usage   []string
+		err     error
+	}
+
+	testCases := []testCase{
+		{
+			// 1000 at 0%, 500 at 10% and 500 at 20%
+			"marginal",
+			[]*withdec128.InputTax{lux(withdec128.Natural, marginal)}, nil,
+			"150", "150", "7.5", []string{"0", "50", "100"}, nil,
+		},
+		{
+			"whole base",
+			[]*withdec128.InputTax{lux(withdec128.Natural, wholeBase)}, nil,
+			"400", "400", "20", []string{"400"}, nil,
+		},
+		{
+			// the vat applies on 2000 + 150
+			"natural under an overtax",
+			[]*withdec128.InputTax{lux(withdec128.Natural, marginal), {CodeValue: "IVA", V: dec128.FromInt(19), Id: 2, Stagee: withdec128.Overtax}}, nil,
+			"558.5", "150", "7.5", []string{"0", "50", "100"}, nil,
+		},
+		{
+			// the brackets apply on 2000 + 20
+			"overtax over a natural",
+			[]*withdec128.InputTax{{CodeValue: "ILA", V: dec128.FromInt(10), Id: 2, Typee: withdec128.Amount}, lux(withdec128.Overtax, marginal)}, nil,
+			"174", "154", "7.6237623762376237623", []string{"0", "50", "104"}, nil,
+		},
+		{
+			"fixed base",
+			[]*withdec128.InputTax{{
+				CodeValue: "LUX", Id: 1, Typee: withdec128.Bracketed, Brackets: marginal,
+				Basee: withdec128.BaseFixed, BaseV: dec128.FromInt(1200),
+			}}, nil,
+			"20", "20", "1.6666666666666666666", []string{"0", "20"}, nil,
+		},
+		{
+			"capped",
+			[]*withdec128.InputTax{{
+				CodeValue: "LUX", Id: 1, Typee: withdec128.Bracketed, Brackets: marginal,
+				Cap: &withdec128.TaxCap{Ceiling: dec128.FromInt(100)},
+			}}, nil,
+			"100", "100", "7.5", []string{"0", "50", "100"}, nil,
+		},
+		{
+			"exempt",
+			[]*withdec128.InputTax{lux(withdec128.Natural, marginal)}, withdec128.Exemptions{{TaxCode: "LUX"}},
+			"0", "0", "0", []string{"0"}, nil,
+		},
+		{
+			"last bracket limited",
+			[]*withdec128.InputTax{lux(withdec128.Natural, taxBrackets(withdec128.BracketsMarginal, "1000", "0", "1500", "10"))}, nil,
+			"", "", "", nil, withdec128.ErrInvalidBrackets,
+		},
+		{
+			"unsorted brackets",
+			[]*withdec128.InputTax{lux(withdec128.Natural, taxBrackets(withdec128.BracketsMarginal, "1500", "0", "1000", "10", "0", "20"))}, nil,
+			"", "", "", nil, withdec128.ErrInvalidBrackets,
+		},
+		{
+			"rate over 100",
+			[]*withdec128.InputTax{lux(withdec128.Natural, taxBrackets(withdec128.BracketsWholeBase, "0", "120"))}, nil,
+			"", "", "", nil, withdec128.ErrInvalidBrackets,
+		},
+		{
+			"without brackets",
+			[]*withdec128.InputTax{lux(withdec128.Natural, nil)}, nil,
+			"", "", "", nil, withdec128.ErrInvalidBrackets,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opt := &withdec128.Options{DetailTaxProcess: withdec128.NewDetailTaxes(), Exempts: tc.exempts}
+			input := &withdec128.Input{
+				UV:      dec128.FromInt(1000),
+				QTY:     dec128.FromInt(2),
+				TaxList: tc.taxes,
+			}
+			output := &withdec128.Output{}
+
+			err := handler.Next(opt, input, output, handler.EntryValidation, handler.Bootstrap, handler.Netter, handler.Taxer, handler.Grosser)
+
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected error %v, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if output.Tax().String() != tc.tax {
+				t.Errorf("expected tax %s, got %s", tc.tax, output.Tax())
+			}
+
+			for _, d := range output.DetailTaxes() {
+				if d.Code() != "LUX" {
+					continue
+				}
+
+				if d.Amount().String() != tc.lux || d.Percent().String() != tc.percent {
+					t.Errorf("expected LUX to be %s at %s%%, got %s at %s%%", tc.lux, tc.percent, d.Amount(), d.Percent())
+				}
+
+				usage := d.(withdec128.BracketDetailer).Brackets()
+				if len(usage) != len(tc.usage) {
+					t.Fatalf("expected %d brackets used, got %+v", len(tc.usage), usage)
+				}
+				for i, u := range usage {
+					if u.Amount.String() != tc.usage[i] {
+						t.Errorf("expected bracket %d to be %s, got %s", u.Bracket, tc.usage[i], u.Amount)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestConvertBracketedTaxes(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	opt := &withdec128.Options{DetailTaxProcess: withdec128.NewDetailTaxes()}
+	input := &withdec128.Input{
+		UV:  dec128.FromInt(1000),
+		QTY: dec128.FromInt(2),
+		TaxList: []*withdec128.InputTax{{
+			CodeValue: "LUX", Id: 1, Typee: withdec128.Bracketed,
+			Brackets: taxBrackets(withdec128.BracketsMarginal, "1000", "0", "1500", "10", "0", "20"),
+		}},
+	}
+	output := &withdec128.Output{}
+
+	if err := handler.Next(opt, input, output, handler.EntryValidation, handler.Bootstrap, handler.Netter, handler.Taxer, handler.Grosser); err != nil {
+		t.Fatal(err)
+	}
+
+	rate := money.ExchangeRate{From: "CLP", To: "USD", Rate: dec128.FromString("0.01")}
+	ro := withdec128.ConvertOutput(output, rate, money.MustLookup("USD"))
+
+	usage := ro.DetailTaxes()[0].(withdec128.BracketDetailer).Brackets()
+	bases, amounts := []string{"10", "5", "5"}, []string{"0", "0.5", "1"}
+	if len(usage) != len(bases) {
+		t.Fatalf("expected %d brackets used, got %+v", len(bases), usage)
+	}
+	for i, u := range usage {
+		if u.Bracket != i || u.Base.String() != bases[i] || u.Amount.String() != amounts[i] {
+			t.Errorf("expected bracket %d to tax %s on %s, got bracket %d taxing %s on %s", i, amounts[i], bases[i], u.Bracket, u.Amount, u.Base)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/money"
+	"github.com/profe-ajedrez/badassitron/withdec128"
+	"github.com/profe-ajedrez/badassitron/withdec128/handler"
+)
+
+func TestTaxCaps(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		name  string
+		taxes []*withdec128.InputTax
+		tax   string
+		raws  map[string]string
+		amts  map[string]string
+		hits  map[string]withdec128.CapHit
+		err   error
+	}
+
+	testCases := []testCase{
+		{
+			"percentual over its ceiling",
+			[]*withdec128.InputTax{{CodeValue: "STAMP", V: dec128.FromInt(1), Id: 1, Cap: &withdec128.TaxCap{Ceiling: dec128.FromInt(15)}}},
+			"15",
+			map[string]string{"STAMP": "20"},
+			map[string]string{"STAMP": "15"},
+			map[string]withdec128.CapHit{"STAMP": withdec128.CapCeiling},
+			nil,
+		},
+		{
+			"percentual within its cap",
+			[]*withdec128.InputTax{{CodeValue: "STAMP", V: dec128.FromInt(1), Id: 1, Cap: &withdec128.TaxCap{Floor: dec128.FromInt(5), Ceiling: dec128.FromInt(50)}}},
+			"20",
+			map[string]string{"STAMP": "20"},
+			map[string]string{"STAMP": "20"},
+			map[string]withdec128.CapHit{"STAMP": withdec128.CapNone},
+			nil,
+		},
+		{
+			"line amount under its floor",
+			[]*withdec128.InputTax{{CodeValue: "ECO", V: dec128.FromInt(5), Id: 1, Typee: withdec128.AmountLine, Cap: &withdec128.TaxCap{Floor: dec128.FromInt(10)}}},
+			"10",
+			map[string]string{"ECO": "5"},
+			map[string]string{"ECO": "10"},
+			map[string]withdec128.CapHit{"ECO": withdec128.CapFloor},
+			nil,
+		},
+		{
+			"amount over its ceiling per unit",
+			[]*withdec128.InputTax{{CodeValue: "ECO", V: dec128.FromInt(2), Id: 1, Typee: withdec128.Amount, Cap: &withdec128.TaxCap{Ceiling: dec128.FromString("1.5"), PerUnit: true}}},
+			"3",
+			map[string]string{"ECO": "4"},
+			map[string]string{"ECO": "3"},
+			map[string]withdec128.CapHit{"ECO": withdec128.CapCeiling},
+			nil,
+		},
+		{
+			// 10% of 2000 capped to 100, and the vat applies on 2000 + 100
+			"capped natural under an overtax",
+			[]*withdec128.InputTax{
+				{CodeValue: "ILA", V: dec128.FromInt(10), Id: 1, Cap: &withdec128.TaxCap{Ceiling: dec128.FromInt(100)}},
+				{CodeValue: "IVA", V: dec128.FromInt(19), Id: 2, Stagee: withdec128.Overtax},
+			},
+			"499",
+			map[string]string{"ILA": "200", "IVA": "399"},
+			map[string]string{"ILA": "100", "IVA": "399"},
+			map[string]withdec128.CapHit{"ILA": withdec128.CapCeiling, "IVA": withdec128.CapNone},
+			nil,
+		},
+		{
+			// 10 per liter of 3 liters, at most 20 per unit
+			"specific over its ceiling per unit",
+			[]*withdec128.InputTax{{
+				CodeValue: "FUEL", V: dec128.FromInt(10), Id: 1, Typee: withdec128.Specific,
+				Formula: withdec128.PerAttribute("volume"), Cap: &withdec128.TaxCap{Ceiling: dec128.FromInt(20), PerUnit: true},
+			}},
+			"40",
+			map[string]string{"FUEL": "60"},
+			map[string]string{"FUEL": "40"},
+			map[string]withdec128.CapHit{"FUEL": withdec128.CapCeiling},
+			nil,
+		},
+		{
+			"ceiling under the floor",
+			[]*withdec128.InputTax{{V: dec128.FromInt(1), Id: 1, Cap: &withdec128.TaxCap{Floor: dec128.FromInt(10), Ceiling: dec128.FromInt(5)}}},
+			"", nil, nil, nil,
+			withdec128.ErrInvalidTaxCap,
+		},
+		{
+			"negative floor",
+			[]*withdec128.InputTax{{V: dec128.FromInt(1), Id: 1, Cap: &withdec128.TaxCap{Floor: dec128.FromInt(-1)}}},
+			"", nil, nil, nil,
+			withdec128.ErrInvalidTaxCap,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opt := &withdec128.Options{DetailTaxProcess: withdec128.NewDetailTaxes()}
+			input := &withdec128.Input{
+				UV:      dec128.FromInt(1000),
+				QTY:     dec128.FromInt(2),
+				TaxList: tc.taxes,
+				Attrs:   withdec128.Attributes{"volume": dec128.FromInt(3)},
+			}
+			output := &withdec128.Output{}
+
+			err := handler.Next(opt, input, output, handler.EntryValidation, handler.Bootstrap, handler.Netter, handler.Taxer, handler.Grosser)
+
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected error %v, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if output.Tax().String() != tc.tax {
+				t.Errorf("expected tax %s, got %s", tc.tax, output.Tax())
+			}
+
+			for _, d := range output.DetailTaxes() {
+				if d.RawAmount().String() != tc.raws[d.Code()] || d.Amount().String() != tc.amts[d.Code()] {
+					t.Errorf("expected %s to be %s capped to %s, got %s capped to %s",
+						d.Code(), tc.raws[d.Code()], tc.amts[d.Code()], d.RawAmount(), d.Amount())
+				}
+				if hit := d.(withdec128.CapDetailer).CapHit(); hit != tc.hits[d.Code()] {
+					t.Errorf("expected %s to hit %d, got %d", d.Code(), tc.hits[d.Code()], hit)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertTaxCaps(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	opt := &withdec128.Options{DetailTaxProcess: withdec128.NewDetailTaxes()}
+	input := &withdec128.Input{
+		UV:  dec128.FromInt(1000),
+		QTY: dec128.FromInt(2),
+		TaxList: []*withdec128.InputTax{
+			{CodeValue: "STAMP", V: dec128.FromInt(1), Id: 1, Cap: &withdec128.TaxCap{Ceiling: dec128.FromInt(15)}},
+			{CodeValue: "ECO", V: dec128.FromInt(5), Id: 2, Typee: withdec128.AmountLine, Cap: &withdec128.TaxCap{Floor: dec128.FromInt(10)}},
+		},
+	}
+	output := &withdec128.Output{}
+
+	if err := handler.Next(opt, input, output, handler.EntryValidation, handler.Bootstrap, handler.Netter, handler.Taxer, handler.Grosser); err != nil {
+		t.Fatal(err)
+	}
+
+	rate := money.ExchangeRate{From: "CLP", To: "USD", Rate: dec128.FromString("0.1")}
+	ro := withdec128.ConvertOutput(output, rate, money.MustLookup("USD"))
+
+	hits := map[string]withdec128.CapHit{"STAMP": withdec128.CapCeiling, "ECO": withdec128.CapFloor}
+	raws := map[string]string{"STAMP": "2", "ECO": "0.5"}
+	for _, d := range ro.DetailTaxes() {
+		if hit := d.(withdec128.CapDetailer).CapHit(); hit != hits[d.Code()] || d.RawAmount().String() != raws[d.Code()] {
+			t.Errorf("expected %s to hit %v from %s, got %v from %s", d.Code(), hits[d.Code()], raws[d.Code()], hit, d.RawAmount())
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/money"
+	"github.com/profe-ajedrez/badassitron/withdec128"
+	"github.com/profe-ajedrez/badassitron/withdec128/handler"
+)
+
+func TestCashRounder(t *testing.T) {
+	type testCase struct {
+		name       string
+		rule       withdec128.CashRounding
+		curr       money.Currency
+		uv         string
+		tender     withdec128.Tender
+		payable    string
+		adjustment string
+		err        error
+	}
+
+	testCases := []testCase{
+		{"chilean rounds 5 down", withdec128.ChileanCashRounding(), money.Currency{}, "1235", withdec128.TenderCash, "1230", "-5", nil},
+		{"chilean rounds 6 up", withdec128.ChileanCashRounding(), money.Currency{}, "1236", withdec128.TenderCash, "1240", "4", nil},
+		{"chilean card is not rounded", withdec128.ChileanCashRounding(), money.Currency{}, "1236", withdec128.TenderCard, "1236", "0", nil},
+		{"swiss rounds to 0.05", withdec128.SwissCashRounding(), money.Currency{}, "10.025", withdec128.TenderCash, "10.05", "0.025", nil},
+		{"swiss rounds down", withdec128.SwissCashRounding(), money.Currency{}, "10.02", withdec128.TenderCash, "10", "-0.02", nil},
+		{"increment from currency", withdec128.CashRounding{}, money.MustLookup("CHF"), "10.07", withdec128.TenderCash, "10.05", "-0.02", nil},
+		{"increment without currency", withdec128.CashRounding{}, money.Currency{}, "10.07", withdec128.TenderCash, "", "", withdec128.ErrNoCurrency},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opt := &withdec128.Options{DetailTaxProcess: withdec128.NewDetailTaxes(), Curr: tc.curr}
+			input := &withdec128.Input{
+				UV:         dec128.FromString(tc.uv),
+				QTY:        withdec128.One(),
+				TenderType: tc.tender,
+			}
+			output := &withdec128.Output{}
+
+			err := handler.Next(
+				opt, input, output,
+				handler.EntryValidation,
+				handler.Bootstrap,
+				handler.Netter,
+				handler.Taxer,
+				handler.Grosser,
+				handler.CashRounder(tc.rule),
+			)
+
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected error %v, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if output.Payable().String() != tc.payable {
+				t.Errorf("expected payable %s, got %s", tc.payable, output.Payable())
+			}
+			if output.RoundingAdjustment().String() != tc.adjustment {
+				t.Errorf("expected adjustment %s, got %s", tc.adjustment, output.RoundingAdjustment())
+			}
+			if !output.Gross().Equal(dec128.FromString(tc.uv)) {
+				t.Errorf("expected gross to stay %s, got %s", tc.uv, output.Gross())
+			}
+		})
+	}
+}
+
+func TestCashRounderKeepsTaxes(t *testing.T) {
+	opt := &withdec128.Options{DetailTaxProcess: withdec128.NewDetailTaxes()}
+	input := &withdec128.Input{
+		UV:         dec128.FromInt(1000),
+		QTY:        withdec128.One(),
+		TaxList:    []*withdec128.InputTax{{V: dec128.FromInt(19), Typee: withdec128.Percentual, Id: 1}},
+		TenderType: withdec128.TenderCash,
+	}
+	output := &withdec128.Output{}
+
+	err := handler.Next(
+		opt, input, output,
+		handler.EntryValidation,
+		handler.Bootstrap,
+		handler.Netter,
+		handler.Taxer,
+		handler.Grosser,
+		handler.CashRounder(withdec128.ChileanCashRounding()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !output.Tax().Equal(dec128.FromInt(190)) || !output.Gross().Equal(dec128.FromInt(1190)) {
+		t.Errorf("expected tax 190 and gross 1190, got %s and %s", output.Tax(), output.Gross())
+	}
+
+	if output.Payable().String() != "1190" || !output.RoundingAdjustment().IsZero() {
+		t.Errorf("expected payable 1190 without adjustment, got %s and %s", output.Payable(), output.RoundingAdjustment())
+	}
+
+	input.UV = dec128.FromInt(1005)
+	if err := handler.Next(opt, input, output, handler.EntryValidation, handler.Bootstrap, handler.Netter, handler.Taxer, handler.Grosser, handler.CashRounder(withdec128.ChileanCashRounding())); err != nil {
+		t.Fatal(err)
+	}
+
+	// 1005 * 1.19 = 1195.95, rounded half toward zero to 1200
+	if output.Payable().String() != "1200" || output.RoundingAdjustment().String() != "4.05" {
+		t.Errorf("expected payable 1200 adjusted by 4.05, got %s and %s", output.Payable(), output.RoundingAdjustment())
+	}
+	if output.Tax().String() != "190.95" {
+		t.Errorf("expected tax to stay 190.95, got %s", output.Tax())
+	}
+}
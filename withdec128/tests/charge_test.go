@@ -0,0 +1,276 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/withdec128"
+	"github.com/profe-ajedrez/badassitron/withdec128/handler"
+)
+
+func TestCharger(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	service := withdec128.Charge{Code: "SERV", Kind: withdec128.ChargePercent, Value: dec128.FromInt(10), Taxable: true}
+	tip := withdec128.Charge{Code: "TIP", Kind: withdec128.ChargePercent, Value: dec128.FromInt(10), BeforeDiscount: true}
+	eco := withdec128.Charge{Code: "ECO", Kind: withdec128.ChargeFixed, Value: dec128.FromInt(5), Scope: withdec128.ChargePerUnit, Taxable: true}
+	bag := withdec128.Charge{Code: "BAG", Kind: withdec128.ChargeFixed, Value: dec128.FromInt(7), Scope: withdec128.ChargePerLine}
+
+	type testCase struct {
+		name    string
+		charges []withdec128.Charge
+		net     string
+		tax     string
+		gross   string
+		amounts map[string]string
+		bases   map[string]string
+		err     error
+	}
+
+	testCases := []testCase{
+		{
+			"no charges",
+			nil,
+			"1800", "342", "2142", nil, nil, nil,
+		},
+		{
+			// 10% of 1800, taxed
+			"taxable service charge",
+			[]withdec128.Charge{service},
+			"1980", "376.2", "2356.2",
+			map[string]string{"SERV": "180"},
+			map[string]string{"SERV": "1800"},
+			nil,
+		},
+		{
+			// 10% of 2000, not taxed
+			"tip before discount",
+			[]withdec128.Charge{tip},
+			"1800", "342", "2342",
+			map[string]string{"TIP": "200"},
+			map[string]string{"TIP": "2000"},
+			nil,
+		},
+		{
+			"fixed per unit and line",
+			[]withdec128.Charge{eco, bag},
+			"1810", "343.9", "2160.9",
+			map[string]string{"ECO": "10", "BAG": "7"},
+			map[string]string{"ECO": "0", "BAG": "0"},
+			nil,
+		},
+		{
+			"negative charge",
+			[]withdec128.Charge{{Code: "NEG", Value: dec128.FromInt(-1)}},
+			"", "", "", nil, nil, withdec128.ErrNegativeCharge,
+		},
+		{
+			"unknown kind",
+			[]withdec128.Charge{{Code: "X", Kind: 9, Value: dec128.FromInt(1)}},
+			"", "", "", nil, nil, withdec128.ErrInvalidCharge,
+		},
+		{
+			"unknown scope",
+			[]withdec128.Charge{{Code: "X", Kind: withdec128.ChargeFixed, Value: dec128.FromInt(1), Scope: 9}},
+			"", "", "", nil, nil, withdec128.ErrInvalidCharge,
+		},
+		{
+			"document charge not split",
+			[]withdec128.Charge{{Code: "DEL", Kind: withdec128.ChargeFixed, Value: dec128.FromInt(20), Scope: withdec128.ChargePerDocument}},
+			"", "", "", nil, nil, withdec128.ErrDocumentCharge,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opt := &withdec128.Options{DetailTaxProcess: withdec128.NewDetailTaxes()}
+			input := &withdec128.Input{
+				UV:         dec128.FromInt(1000),
+				QTY:        dec128.FromInt(2),
+				Disc:       dec128.FromInt(10),
+				TaxList:    []*withdec128.InputTax{{CodeValue: "IVA", V: dec128.FromInt(19), Id: 1}},
+				ChargeList: tc.charges,
+			}
+			output := &withdec128.Output{}
+
+			err := handler.Next(
+				opt, input, output,
+				handler.EntryValidation,
+				handler.Bootstrap,
+				handler.Netter,
+				handler.Charger,
+				handler.Taxer,
+				handler.Grosser,
+			)
+
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected error %v, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if output.Net().String() != tc.net || output.Tax().String() != tc.tax || output.Gross().String() != tc.gross {
+				t.Errorf("expected net %s, tax %s and gross %s, got %s, %s and %s",
+					tc.net, tc.tax, tc.gross, output.Net(), output.Tax(), output.Gross())
+			}
+
+			// the charges are not discounted
+			if output.GrossDiscount().String() != "238" {
+				t.Errorf("expected gross discount 238, got %s", output.GrossDiscount())
+			}
+
+			if d := output.DetailTaxes()[0]; !d.Amount().Equal(output.Tax()) {
+				t.Errorf("expected the detailed tax to be %s, got %s", output.Tax(), d.Amount())
+			}
+
+			if len(output.DetailCharges()) != len(tc.charges) {
+				t.Fatalf("expected %d detailed charges, got %d", len(tc.charges), len(output.DetailCharges()))
+			}
+			for _, c := range output.DetailCharges() {
+				if c.Amount().String() != tc.amounts[c.Code()] || c.Base().String() != tc.bases[c.Code()] {
+					t.Errorf("expected %s to be %s on %s, got %s on %s", c.Code(), tc.amounts[c.Code()], tc.bases[c.Code()], c.Amount(), c.Base())
+				}
+			}
+		})
+	}
+}
+
+func TestChargeOnBaseNetWD(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	opt := &withdec128.Options{DetailTaxProcess: withdec128.NewDetailTaxes()}
+	input := &withdec128.Input{
+		UV:         dec128.FromInt(100),
+		QTY:        dec128.FromInt(10),
+		TaxList:    []*withdec128.InputTax{{CodeValue: "IVA", V: dec128.FromInt(10), Id: 1, Basee: withdec128.BaseNetWD}},
+		ChargeList: []withdec128.Charge{{Code: "SERV", Kind: withdec128.ChargeFixed, Value: dec128.FromInt(100), Scope: withdec128.ChargePerLine, Taxable: true}},
+	}
+	output := &withdec128.Output{}
+
+	err := handler.Next(opt, input, output, handler.EntryValidation, handler.Bootstrap, handler.Netter, handler.Charger, handler.Taxer, handler.Grosser)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 10% of 1000 + 100
+	if output.Tax().String() != "110" {
+		t.Errorf("expected tax 110, got %s", output.Tax())
+	}
+	if d := output.DetailTaxes()[0]; d.Amount().String() != "110" || d.Taxable().String() != "1100" {
+		t.Errorf("expected the detailed tax to be 110 on 1100, got %s on %s", d.Amount(), d.Taxable())
+	}
+}
+
+func TestChargeDetailOnLineNet(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	opt := &withdec128.Options{DetailTaxProcess: withdec128.NewDetailTaxes()}
+	input := &withdec128.Input{
+		UV:         dec128.FromInt(100),
+		QTY:        dec128.FromInt(3),
+		TaxList:    []*withdec128.InputTax{{CodeValue: "IVA", V: dec128.FromInt(19), Id: 1}},
+		ChargeList: []withdec128.Charge{{Code: "SERV", Kind: withdec128.ChargeFixed, Value: dec128.FromInt(1), Scope: withdec128.ChargePerLine, Taxable: true}},
+	}
+	output := &withdec128.Output{}
+
+	err := handler.Next(opt, input, output, handler.EntryValidation, handler.Bootstrap, handler.Netter, handler.Charger, handler.Taxer, handler.Grosser)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 19% of 301, which a unit value of 301 / 3 only approximates
+	if d := output.DetailTaxes()[0]; output.Tax().String() != "57.19" || !d.Amount().Equal(output.Tax()) {
+		t.Errorf("expected tax 57.19 detailed, got %s detailed as %s", output.Tax(), d.Amount())
+	}
+}
+
+func TestDocumentCharge(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		name    string
+		charge  withdec128.Charge
+		shares  []string
+		taxes   []string
+		untaxed []string
+		err     error
+	}
+
+	testCases := []testCase{
+		{
+			// split by the net of the lines, 200, 100 and 300
+			"service charge not taxed",
+			withdec128.Charge{Code: "SERV", Kind: withdec128.ChargeFixed, Value: dec128.FromInt(60), Scope: withdec128.ChargePerDocument},
+			[]string{"20", "10", "30"}, []string{"38", "10", "0"}, []string{"20", "10", "30"}, nil,
+		},
+		{
+			"delivery taxed",
+			withdec128.Charge{Code: "DEL", Kind: withdec128.ChargeFixed, Value: dec128.FromInt(60), Scope: withdec128.ChargePerDocument, Taxable: true},
+			[]string{"20", "10", "30"}, []string{"41.8", "11", "0"}, []string{"0", "0", "0"}, nil,
+		},
+		{
+			"charge for the line",
+			withdec128.Charge{Code: "BAG", Kind: withdec128.ChargeFixed, Value: dec128.FromInt(60), Scope: withdec128.ChargePerLine},
+			nil, nil, nil, withdec128.ErrInvalidCharge,
+		},
+		{
+			"percentage",
+			withdec128.Charge{Code: "TIP", Kind: withdec128.ChargePercent, Value: dec128.FromInt(10), Scope: withdec128.ChargePerDocument},
+			nil, nil, nil, withdec128.ErrInvalidCharge,
+		},
+		{
+			"negative",
+			withdec128.Charge{Code: "SERV", Kind: withdec128.ChargeFixed, Value: dec128.FromInt(-1), Scope: withdec128.ChargePerDocument},
+			nil, nil, nil, withdec128.ErrNegativeCharge,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lines := freightLines()
+			inputs := make([]withdec128.Enterable, len(lines))
+			for i, l := range lines {
+				inputs[i] = l
+			}
+
+			shares, err := tc.charge.AllocateTo(2, inputs...)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected error %v, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i, line := range lines {
+				if shares[i].String() != tc.shares[i] {
+					t.Errorf("line %d: expected a share of %s, got %s", i, tc.shares[i], shares[i])
+				}
+
+				output := &withdec128.Output{}
+				opt := &withdec128.Options{DetailTaxProcess: withdec128.NewDetailTaxes()}
+
+				err := handler.Next(opt, line, output, handler.EntryValidation, handler.Bootstrap, handler.Netter, handler.Charger, handler.Taxer, handler.Grosser)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if output.Tax().String() != tc.taxes[i] || output.TotalUntaxedCharges.String() != tc.untaxed[i] {
+					t.Errorf("line %d: expected tax %s and %s of untaxed charges, got %s and %s",
+						i, tc.taxes[i], tc.untaxed[i], output.Tax(), output.TotalUntaxedCharges)
+				}
+				if c := output.DetailCharges()[0]; c.Code() != tc.charge.Code || c.Taxable() != tc.charge.Taxable || c.Freight() {
+					t.Errorf("line %d: expected the share to be a charge %s taxable %v, got %s taxable %v freight %v",
+						i, tc.charge.Code, tc.charge.Taxable, c.Code(), c.Taxable(), c.Freight())
+				}
+			}
+		})
+	}
+}
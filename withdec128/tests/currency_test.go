@@ -0,0 +1,135 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/money"
+	"github.com/profe-ajedrez/badassitron/withdec128"
+	"github.com/profe-ajedrez/badassitron/withdec128/handler"
+)
+
+func TestCurrencyScale(t *testing.T) {
+	opt := &withdec128.Options{
+		Process:          withdec128.FromUV,
+		DetailTaxProcess: withdec128.NewDetailTaxes(),
+	}
+
+	input := &withdec128.CurrencyInput{
+		Input: withdec128.Input{
+			UV:      dec128.FromString("1190"),
+			QTY:     withdec128.Ten(),
+			TaxList: []*withdec128.InputTax{{V: dec128.FromInt(19), Typee: withdec128.Percentual, Id: 1, CodeValue: "IVA"}},
+		},
+		Curr: money.MustLookup("CLP"),
+	}
+	output := &withdec128.CurrencyOutput{}
+
+	if err := startHandler(opt, input, output); err != nil {
+		t.Fatal(err)
+	}
+
+	if opt.Scale() != 0 {
+		t.Errorf("expected scale 0 from CLP, got %d", opt.Scale())
+	}
+
+	if output.Currency().Code != "CLP" {
+		t.Errorf("expected output currency CLP, got %q", output.Currency().Code)
+	}
+
+	if gross := output.Money(output.Gross()).Round(); gross.String() != "14161 CLP" {
+		t.Errorf("expected gross 14161 CLP, got %s", gross)
+	}
+
+	opt.Prec = 6
+	if opt.Scale() != 6 {
+		t.Errorf("expected explicit scale 6 to win over the currency, got %d", opt.Scale())
+	}
+
+	opt.WithCurrency(money.MustLookup("USD"))
+	opt.WithScale(0)
+	if opt.Scale() != 0 {
+		t.Errorf("expected explicit scale 0 to win over USD, got %d", opt.Scale())
+	}
+
+	opt = &withdec128.Options{Curr: money.MustLookup("USD"), PrecSet: true}
+	if opt.Scale() != 0 {
+		t.Errorf("expected a set Prec of 0 to win over USD, got %d", opt.Scale())
+	}
+}
+
+func TestCurrencyConverter(t *testing.T) {
+	rates := money.NewMemoryRates()
+	on := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	if err := rates.Add(money.ExchangeRate{From: "USD", To: "CLP", Rate: dec128.FromString("950.5"), Date: on, Source: "BCCh"}); err != nil {
+		t.Fatal(err)
+	}
+
+	opt := &withdec128.Options{
+		Process:          withdec128.FromUV,
+		DetailTaxProcess: withdec128.NewDetailTaxes(),
+	}
+
+	input := &withdec128.CurrencyInput{
+		Input: withdec128.Input{
+			UV:      withdec128.Hundred(),
+			QTY:     dec128.FromInt(3),
+			TaxList: []*withdec128.InputTax{{V: dec128.FromInt(19), Typee: withdec128.Percentual, Id: 1, CodeValue: "IVA"}},
+		},
+		Curr: money.MustLookup("USD"),
+	}
+	output := &withdec128.CurrencyOutput{}
+
+	err := handler.Next(
+		opt, input, output,
+		handler.EntryValidation,
+		handler.Bootstrap,
+		handler.Netter,
+		handler.Taxer,
+		handler.Grosser,
+		handler.CurrencyConverter(rates, "CLP", on.Add(10*time.Hour)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ro := output.Reporting
+	if ro == nil {
+		t.Fatal("expected the output converted into CLP")
+	}
+
+	if ro.Currency().Code != "CLP" || !ro.ExchangeRate().Rate.Equal(dec128.FromString("950.5")) || ro.ExchangeRate().Source != "BCCh" {
+		t.Errorf("unexpected reporting currency %s or rate %+v", ro.Currency(), ro.ExchangeRate())
+	}
+
+	expected := map[string][2]dec128.Dec128{
+		"net":   {ro.Net(), dec128.FromInt(285150)},
+		"tax":   {ro.Tax(), dec128.FromInt(54179)},
+		"gross": {ro.Gross(), dec128.FromInt(339329)},
+	}
+	for name, v := range expected {
+		if !v[0].Equal(v[1]) {
+			t.Errorf("expected converted %s %s, got %s", name, v[1], v[0])
+		}
+	}
+
+	if len(ro.DetailTaxes()) != 1 {
+		t.Fatalf("expected 1 converted tax detail, got %d", len(ro.DetailTaxes()))
+	}
+
+	tax := ro.DetailTaxes()[0]
+	if !tax.Amount().Equal(dec128.FromInt(54179)) || !tax.Taxable().Equal(dec128.FromInt(285150)) || !tax.Percent().Equal(dec128.FromInt(19)) {
+		t.Errorf("unexpected converted tax detail amount %s taxable %s percent %s", tax.Amount(), tax.Taxable(), tax.Percent())
+	}
+
+	if !output.Gross().Equal(dec128.FromInt(357)) {
+		t.Errorf("expected the USD gross to stay 357, got %s", output.Gross())
+	}
+
+	err = handler.Next(opt, input, &withdec128.Output{}, handler.CurrencyConverter(rates, "CLP", on))
+	if !errors.Is(err, withdec128.ErrNotReportable) {
+		t.Errorf("expected %v, got %v", withdec128.ErrNotReportable, err)
+	}
+}
@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/withdec128"
+	"github.com/profe-ajedrez/badassitron/withdec128/handler"
+)
+
+func TestDetailTaxes(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		name    string
+		taxes   []*withdec128.InputTax
+		codes   []string
+		amounts []string
+	}
+
+	testCases := []testCase{
+		{
+			"in the order they were bound",
+			[]*withdec128.InputTax{
+				{CodeValue: "IVA", V: dec128.FromInt(19), Id: 7},
+				{CodeValue: "ILA", V: dec128.FromInt(10), Id: 3, Typee: withdec128.Amount},
+				{CodeValue: "IGIC", V: dec128.FromInt(7), Id: 5},
+			},
+			[]string{"IVA", "ILA", "IGIC"},
+			[]string{"380", "20", "140"},
+		},
+		{
+			// 19% of 2000 + 20, like the totals
+			"overtax on the natural taxes",
+			[]*withdec128.InputTax{
+				{CodeValue: "ILA", V: dec128.FromInt(10), Id: 1, Typee: withdec128.Amount},
+				{CodeValue: "IVA", V: dec128.FromInt(19), Id: 2, Stagee: withdec128.Overtax},
+			},
+			[]string{"ILA", "IVA"},
+			[]string{"20", "383.8"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opt := &withdec128.Options{DetailTaxProcess: withdec128.NewDetailTaxes()}
+			input := &withdec128.Input{
+				UV:      dec128.FromInt(1000),
+				QTY:     dec128.FromInt(2),
+				TaxList: tc.taxes,
+			}
+			output := &withdec128.Output{}
+
+			err := handler.Next(opt, input, output, handler.EntryValidation, handler.Bootstrap, handler.Netter, handler.Taxer, handler.Grosser)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			details := output.DetailTaxes()
+			if len(details) != len(tc.codes) {
+				t.Fatalf("expected %d detailed taxes, got %d", len(tc.codes), len(details))
+			}
+
+			charged := dec128.Zero
+			for i, d := range details {
+				if d.Code() != tc.codes[i] || d.Amount().String() != tc.amounts[i] {
+					t.Errorf("expected tax %d to be %s of %s, got %s of %s", i, tc.codes[i], tc.amounts[i], d.Code(), d.Amount())
+				}
+				if !d.Taxable().Equal(output.Net()) {
+					t.Errorf("expected %s to be taxed on %s, got %s", d.Code(), output.Net(), d.Taxable())
+				}
+				charged = charged.Add(d.Amount())
+			}
+			if !charged.Equal(output.Tax()) {
+				t.Errorf("expected the detailed taxes to add up to %s, got %s", output.Tax(), charged)
+			}
+		})
+	}
+}
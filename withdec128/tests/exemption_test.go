@@ -0,0 +1,204 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/money"
+	"github.com/profe-ajedrez/badassitron/withdec128"
+	"github.com/profe-ajedrez/badassitron/withdec128/handler"
+)
+
+func equalBases(bases map[string]dec128.Dec128, want map[string]string) bool {
+	if len(bases) != len(want) {
+		return false
+	}
+	for code, base := range bases {
+		if base.String() != want[code] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestExemptions(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	taxes := []*withdec128.InputTax{
+		{CodeValue: "ILA", V: dec128.FromInt(10), Id: 1, Typee: withdec128.Amount},
+		{CodeValue: "IVA", V: dec128.FromInt(19), Id: 2, Stagee: withdec128.Overtax},
+		{CodeValue: "EXC", V: dec128.FromInt(3), Id: 3, Typee: withdec128.Specific, Stagee: withdec128.Bypass},
+	}
+
+	exemptIVA := withdec128.Exemption{TaxCode: "IVA", Kind: withdec128.Exempt, Certificate: "CERT-1", Reason: "art. 12"}
+	exemptEXC := withdec128.Exemption{TaxCode: "exc", Kind: withdec128.Exempt, Reason: "diplomatic"}
+	zeroIVA := withdec128.Exemption{TaxCode: "iva", Kind: withdec128.ZeroRated, Reason: "export"}
+
+	type testCase struct {
+		name      string
+		customer  withdec128.Exemptions
+		line      withdec128.Exemptions
+		tax       string
+		exempt    map[string]string
+		zeroRated map[string]string
+		reasons   map[string]string
+		err       error
+	}
+
+	testCases := []testCase{
+		{
+			"customer exempt",
+			withdec128.Exemptions{exemptIVA, exemptEXC}, nil,
+			"20", map[string]string{"IVA": "2000", "EXC": "2000"}, nil,
+			map[string]string{"IVA": "art. 12", "EXC": "diplomatic"},
+			nil,
+		},
+		{
+			"line zero-rated over the customer",
+			withdec128.Exemptions{exemptIVA, exemptEXC}, withdec128.Exemptions{zeroIVA},
+			"20", map[string]string{"EXC": "2000"}, map[string]string{"IVA": "2000"},
+			map[string]string{"IVA": "export", "EXC": "diplomatic"},
+			nil,
+		},
+		{
+			// the 2000 the IVA is charged on are not reported as exempt
+			"exempt from some taxes only",
+			withdec128.Exemptions{{TaxCode: "ILA", Reason: "art. 40"}, exemptEXC}, nil,
+			"380", map[string]string{"ILA": "2000", "EXC": "2000"}, nil,
+			map[string]string{"ILA": "art. 40", "EXC": "diplomatic"},
+			nil,
+		},
+		{
+			"specific tax without formula nor exemption",
+			withdec128.Exemptions{exemptIVA}, nil,
+			"", nil, nil, nil,
+			withdec128.ErrNoFormula,
+		},
+		{
+			"invalid exemption",
+			withdec128.Exemptions{exemptEXC}, withdec128.Exemptions{{Reason: "no tax code"}},
+			"", nil, nil, nil,
+			withdec128.ErrInvalidExemption,
+		},
+		{
+			"unknown kind",
+			withdec128.Exemptions{exemptEXC, {TaxCode: "IVA", Kind: 5}}, nil,
+			"", nil, nil, nil,
+			withdec128.ErrInvalidExemption,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opt := &withdec128.Options{DetailTaxProcess: withdec128.NewDetailTaxes(), Exempts: tc.customer}
+			input := &withdec128.Input{
+				UV:      dec128.FromInt(1000),
+				QTY:     dec128.FromInt(2),
+				TaxList: taxes,
+				Exempts: tc.line,
+			}
+			output := &withdec128.Output{}
+
+			err := handler.Next(opt, input, output, handler.EntryValidation, handler.Bootstrap, handler.Netter, handler.Taxer, handler.Grosser)
+
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected error %v, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if output.Tax().String() != tc.tax {
+				t.Errorf("expected tax %s, got %s", tc.tax, output.Tax())
+			}
+			if !equalBases(output.ExemptBases, tc.exempt) || !equalBases(output.ZeroRatedBases, tc.zeroRated) {
+				t.Errorf("expected exempt bases %v and zero-rated bases %v, got %v and %v", tc.exempt, tc.zeroRated, output.ExemptBases, output.ZeroRatedBases)
+			}
+
+			details := output.DetailTaxes()
+			if len(details) != len(taxes) {
+				t.Fatalf("expected %d detailed taxes, got %d", len(taxes), len(details))
+			}
+
+			for _, d := range details {
+				ex := d.(withdec128.ExemptTaxInformer).Exemption()
+
+				want, exempted := tc.reasons[d.Code()]
+				if !exempted {
+					if ex != nil {
+						t.Errorf("expected %s not to be exempted, got %+v", d.Code(), ex)
+					}
+					continue
+				}
+
+				if ex == nil || ex.Reason != want {
+					t.Errorf("expected %s to be exempted for %s, got %+v", d.Code(), want, ex)
+				}
+				if !d.Amount().IsZero() {
+					t.Errorf("expected %s to be zero, got %s", d.Code(), d.Amount())
+				}
+			}
+		})
+	}
+}
+
+func TestNoExemptions(t *testing.T) {
+	opt := &withdec128.Options{DetailTaxProcess: withdec128.NewDetailTaxes()}
+	input := &withdec128.Input{
+		UV:      dec128.FromInt(1000),
+		QTY:     dec128.FromInt(2),
+		TaxList: []*withdec128.InputTax{{CodeValue: "IVA", V: dec128.FromInt(19), Id: 1}},
+	}
+	output := &withdec128.Output{}
+
+	if err := handler.Next(opt, input, output, handler.EntryValidation, handler.Bootstrap, handler.Netter, handler.Taxer, handler.Grosser); err != nil {
+		t.Fatal(err)
+	}
+
+	if output.ExemptBases != nil || output.ZeroRatedBases != nil {
+		t.Errorf("expected no exempt bases, got %v and %v", output.ExemptBases, output.ZeroRatedBases)
+	}
+	if ex := output.DetailTaxes()[0].(withdec128.ExemptTaxInformer).Exemption(); ex != nil {
+		t.Errorf("expected no exemption, got %+v", ex)
+	}
+}
+
+func TestConvertExemptions(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	opt := &withdec128.Options{
+		DetailTaxProcess: withdec128.NewDetailTaxes(),
+		Exempts:          withdec128.Exemptions{{TaxCode: "ILA", Reason: "art. 40"}, {TaxCode: "IVA", Kind: withdec128.ZeroRated, Reason: "export"}},
+	}
+	input := &withdec128.Input{
+		UV:  dec128.FromInt(1000),
+		QTY: dec128.FromInt(2),
+		TaxList: []*withdec128.InputTax{
+			{CodeValue: "ILA", V: dec128.FromInt(10), Id: 1, Typee: withdec128.Amount},
+			{CodeValue: "IVA", V: dec128.FromInt(19), Id: 2, Stagee: withdec128.Overtax},
+		},
+	}
+	output := &withdec128.Output{}
+
+	if err := handler.Next(opt, input, output, handler.EntryValidation, handler.Bootstrap, handler.Netter, handler.Taxer, handler.Grosser); err != nil {
+		t.Fatal(err)
+	}
+
+	rate := money.ExchangeRate{From: "CLP", To: "USD", Rate: dec128.FromString("0.001")}
+	ro := withdec128.ConvertOutput(output, rate, money.MustLookup("USD"))
+
+	if !equalBases(ro.ExemptBases, map[string]string{"ILA": "2"}) || !equalBases(ro.ZeroRatedBases, map[string]string{"IVA": "2"}) {
+		t.Errorf("expected exempt base 2 for ILA and zero-rated base 2 for IVA, got %v and %v", ro.ExemptBases, ro.ZeroRatedBases)
+	}
+
+	for i, d := range ro.DetailTaxes() {
+		want := output.DetailTaxes()[i].(withdec128.ExemptTaxInformer).Exemption()
+		if ex := d.(withdec128.ExemptTaxInformer).Exemption(); ex == nil || *ex != *want {
+			t.Errorf("expected %s to keep its exemption %+v, got %+v", d.Code(), want, ex)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/withdec128"
+	"github.com/profe-ajedrez/badassitron/withdec128/handler"
+)
+
+func freightLines() []*withdec128.Input {
+	return []*withdec128.Input{
+		{
+			UV: dec128.FromInt(100), QTY: dec128.FromInt(2),
+			TaxList: []*withdec128.InputTax{{CodeValue: "IVA", V: dec128.FromInt(19), Id: 1}},
+			Attrs:   withdec128.Attributes{"weight": dec128.FromInt(1)},
+		},
+		{
+			UV: dec128.FromInt(50), QTY: dec128.FromInt(4), Disc: dec128.FromInt(50),
+			TaxList: []*withdec128.InputTax{{CodeValue: "IVA-R", V: dec128.FromInt(10), Id: 1}},
+			Attrs:   withdec128.Attributes{"weight": dec128.FromInt(3)},
+		},
+		{
+			UV: dec128.FromInt(300), QTY: dec128.FromInt(1),
+			Attrs: withdec128.Attributes{"weight": dec128.FromInt(0)},
+		},
+	}
+}
+
+func TestFreightAllocation(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		name   string
+		key    withdec128.AllocationKey
+		shares []string
+		taxes  []string
+		total  string
+	}
+
+	testCases := []testCase{
+		{"by value", withdec128.AllocateByValue, []string{"20", "10", "30"}, []string{"3.8", "1", "0"}, "4.8"},
+		{"by quantity", withdec128.AllocateByQty, []string{"17.14", "34.29", "8.57"}, []string{"3.2566", "3.429", "0"}, "6.6856"},
+		{"by weight", withdec128.AllocateByWeight, []string{"8.57", "51.43", "0"}, []string{"1.6283", "5.143", "0"}, "6.7713"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lines := freightLines()
+			inputs := make([]withdec128.Enterable, len(lines))
+			for i, l := range lines {
+				inputs[i] = l
+			}
+
+			freight := withdec128.Freight{Code: "FRT", Amount: dec128.FromInt(60), Key: tc.key}
+			shares, err := freight.AllocateTo(2, inputs...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			outputs := make([]withdec128.Outputable, len(lines))
+			for i, line := range lines {
+				if shares[i].String() != tc.shares[i] {
+					t.Errorf("line %d: expected a share of %s, got %s", i, tc.shares[i], shares[i])
+				}
+
+				output := &withdec128.Output{}
+				opt := &withdec128.Options{DetailTaxProcess: withdec128.NewDetailTaxes()}
+
+				err := handler.Next(
+					opt, line, output,
+					handler.EntryValidation,
+					handler.Bootstrap,
+					handler.Netter,
+					handler.Charger,
+					handler.Taxer,
+					handler.Grosser,
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+				outputs[i] = output
+
+				if output.FreightTax().String() != tc.taxes[i] {
+					t.Errorf("line %d: expected a freight tax of %s, got %s", i, tc.taxes[i], output.FreightTax())
+				}
+				if !output.TotalTaxedCharges.Equal(shares[i]) {
+					t.Errorf("line %d: expected %s of freight added to the net, got %s", i, shares[i], output.TotalTaxedCharges)
+				}
+			}
+
+			amount, tax := withdec128.FreightTotals(outputs...)
+			if !amount.Equal(freight.Amount) || tax.String() != tc.total {
+				t.Errorf("expected %s of freight with %s of tax, got %s with %s", freight.Amount, tc.total, amount, tax)
+			}
+		})
+	}
+}
+
+func TestFreightAllocationErrors(t *testing.T) {
+	type testCase struct {
+		name    string
+		freight withdec128.Freight
+		lines   func() []*withdec128.Input
+		err     error
+	}
+
+	testCases := []testCase{
+		{
+			"negative freight",
+			withdec128.Freight{Amount: dec128.FromInt(-1)},
+			freightLines,
+			withdec128.ErrNegativeCharge,
+		},
+		{
+			"unknown key",
+			withdec128.Freight{Amount: dec128.FromInt(1), Key: 9},
+			freightLines,
+			withdec128.ErrInvalidFreight,
+		},
+		{
+			"missing weight",
+			withdec128.Freight{Amount: dec128.FromInt(1), Key: withdec128.AllocateByWeight, WeightAttribute: "kg"},
+			freightLines,
+			withdec128.ErrMissingAttribute,
+		},
+		{
+			"no weight at all",
+			withdec128.Freight{Amount: dec128.FromInt(1), Key: withdec128.AllocateByWeight},
+			func() []*withdec128.Input { return freightLines()[2:] },
+			withdec128.ErrInvalidFreight,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var inputs []withdec128.Enterable
+			for _, l := range tc.lines() {
+				inputs = append(inputs, l)
+			}
+
+			if _, err := tc.freight.AllocateTo(2, inputs...); !errors.Is(err, tc.err) {
+				t.Errorf("expected error %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
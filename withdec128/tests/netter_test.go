@@ -0,0 +1,53 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/withdec128"
+	"github.com/profe-ajedrez/badassitron/withdec128/handler"
+)
+
+func TestNetterDivisionRounding(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		name string
+		mode dec128.RoundingMode
+		uv   string
+		qty  string
+		disc string
+		want string
+	}
+
+	// 0.0000000000000000005 * 2 * 0.9 / 2 = 0.00000000000000000045, a tie at the default precision
+	testCases := []testCase{
+		{"half away from zero by default", dec128.RoundingHalfAwayFromZero, "0.0000000000000000005", "2", "10", "0.0000000000000000005"},
+		{"half even", dec128.RoundingHalfEven, "0.0000000000000000005", "2", "10", "0.0000000000000000004"},
+		{"toward zero", dec128.RoundingTowardZero, "0.0000000000000000005", "2", "10", "0.0000000000000000004"},
+		{"up", dec128.RoundingUp, "0.0000000000000000005", "2", "10", "0.0000000000000000005"},
+		{"exact", dec128.RoundingHalfAwayFromZero, "10", "3", "10", "9"},
+		{"exact with odd quantity", dec128.RoundingTowardZero, "10", "7", "12.5", "8.75"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opt := &withdec128.Options{DetailTaxProcess: withdec128.NewDetailTaxes(), DivMode: tc.mode}
+			input := &withdec128.Input{
+				UV:   dec128.FromString(tc.uv),
+				QTY:  dec128.FromString(tc.qty),
+				Disc: dec128.FromString(tc.disc),
+			}
+			output := &withdec128.Output{}
+
+			err := handler.Next(opt, input, output, handler.EntryValidation, handler.Bootstrap, handler.Netter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if output.DiscontedUnitary().String() != tc.want {
+				t.Errorf("expected discounted unitary %s, got %s", tc.want, output.DiscontedUnitary())
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/withdec128"
+	"github.com/profe-ajedrez/badassitron/withdec128/handler"
+)
+
+func priceTiers(tiers ...string) []withdec128.PriceTier {
+	pt := make([]withdec128.PriceTier, 0, len(tiers)/2)
+	for i := 0; i < len(tiers); i += 2 {
+		pt = append(pt, withdec128.PriceTier{UpTo: dec128.FromString(tiers[i]), Price: dec128.FromString(tiers[i+1])})
+	}
+	return pt
+}
+
+func TestTierPricer(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	wholesale := withdec128.PriceList{
+		Name: "wholesale",
+		Mode: withdec128.PricingAllUnits,
+		Prices: map[string][]withdec128.PriceTier{
+			"A": priceTiers("10", "5", "100", "4.5", "0", "4"),
+			"B": priceTiers("10", "20"),
+		},
+	}
+
+	graduated := withdec128.PriceList{
+		Name: "graduated",
+		Mode: withdec128.PricingGraduated,
+		Prices: map[string][]withdec128.PriceTier{
+			"A": priceTiers("10", "5", "100", "4.5", "0", "4"),
+			"C": priceTiers("2", "10", "0", "9"),
+		},
+	}
+
+	type testCase struct {
+		name    string
+		lists   []withdec128.PriceList
+		product string
+		qty     string
+		uv      string
+		net     string
+		source  string
+		tier    int
+		usage   int
+		err     error
+	}
+
+	testCases := []testCase{
+		{"all units first tier", []withdec128.PriceList{wholesale}, "A", "10", "5", "50", "wholesale", 0, 1, nil},
+		{"all units second tier", []withdec128.PriceList{wholesale}, "A", "15", "4.5", "67.5", "wholesale", 1, 1, nil},
+		{"all units unlimited tier", []withdec128.PriceList{wholesale}, "A", "1000", "4", "4000", "wholesale", 2, 1, nil},
+		{"graduated first tier", []withdec128.PriceList{graduated}, "A", "4", "5", "20", "graduated", 0, 1, nil},
+		{"graduated second tier", []withdec128.PriceList{graduated}, "A", "15", "4.8333333333333333333", "72.5", "graduated", 1, 2, nil},
+		{"graduated third tier", []withdec128.PriceList{graduated}, "A", "200", "4.275", "855", "graduated", 2, 3, nil},
+		{"first list having the product", []withdec128.PriceList{graduated, wholesale}, "B", "5", "20", "100", "wholesale", 0, 1, nil},
+		{"fractional quantity", []withdec128.PriceList{graduated}, "C", "2.5", "9.8", "24.5", "graduated", 1, 2, nil},
+		{"over the last tier", []withdec128.PriceList{wholesale}, "B", "11", "", "", "", 0, 0, withdec128.ErrQtyOverTiers},
+		{"not in any list", []withdec128.PriceList{wholesale, graduated}, "D", "1", "", "", "", 0, 0, withdec128.ErrProductNotPriced},
+		{"no product", []withdec128.PriceList{wholesale}, "", "1", "", "", "", 0, 0, withdec128.ErrNoProduct},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opt := &withdec128.Options{DetailTaxProcess: withdec128.NewDetailTaxes()}
+			input := &withdec128.Input{
+				UV:        dec128.FromInt(999),
+				QTY:       dec128.FromString(tc.qty),
+				ProductID: tc.product,
+			}
+			output := &withdec128.Output{}
+
+			err := handler.Next(
+				opt, input, output,
+				handler.EntryValidation,
+				handler.TierPricer(tc.lists...),
+				handler.Bootstrap,
+				handler.Netter,
+				handler.Taxer,
+				handler.Grosser,
+			)
+
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected error %v, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if output.Unitary().String() != tc.uv {
+				t.Errorf("expected unit value %s, got %s", tc.uv, output.Unitary())
+			}
+			if output.Net().String() != tc.net {
+				t.Errorf("expected net %s, got %s", tc.net, output.Net())
+			}
+			if !input.UV.Equal(dec128.FromInt(999)) {
+				t.Errorf("expected the input to keep its unit value 999, got %s", input.UV)
+			}
+
+			pd := output.Pricing
+			if pd == nil {
+				t.Fatal("expected a pricing detail")
+			}
+			if pd.Source != tc.source || pd.Product != tc.product || pd.Tier != tc.tier || len(pd.Usage) != tc.usage {
+				t.Errorf("expected %s/%s tier %d using %d tiers, got %s/%s tier %d using %d tiers",
+					tc.source, tc.product, tc.tier, tc.usage, pd.Source, pd.Product, pd.Tier, len(pd.Usage))
+			}
+
+			var qty, amount dec128.Dec128
+			for _, u := range pd.Usage {
+				qty = qty.Add(u.Qty)
+				amount = amount.Add(u.Amount)
+			}
+			if !qty.Equal(dec128.FromString(tc.qty)) || !amount.Equal(pd.Amount) {
+				t.Errorf("expected the tiers to add up to %s units and %s, got %s and %s", tc.qty, pd.Amount, qty, amount)
+			}
+		})
+	}
+}
+
+func TestPriceListValidation(t *testing.T) {
+	testCases := map[string][]withdec128.PriceTier{
+		"unsorted":            priceTiers("10", "5", "5", "4"),
+		"repeated":            priceTiers("10", "5", "10", "4"),
+		"unlimited not last":  priceTiers("0", "5", "10", "4"),
+		"negative limit":      priceTiers("-1", "5"),
+		"negative price":      priceTiers("10", "-5"),
+		"missing tiers":       nil,
+		"unlimited last only": priceTiers("10", "5", "0", "4"),
+	}
+
+	want := map[string]error{
+		"unsorted":            withdec128.ErrInvalidTiers,
+		"repeated":            withdec128.ErrInvalidTiers,
+		"unlimited not last":  withdec128.ErrInvalidTiers,
+		"negative limit":      withdec128.ErrInvalidTiers,
+		"negative price":      withdec128.ErrNegativeUnitary,
+		"missing tiers":       withdec128.ErrProductNotPriced,
+		"unlimited last only": nil,
+	}
+
+	for name, tiers := range testCases {
+		t.Run(name, func(t *testing.T) {
+			pl := withdec128.PriceList{Prices: map[string][]withdec128.PriceTier{"A": tiers}}
+			_, err := pl.Price("A", dec128.FromInt(1), dec128.RoundingHalfAwayFromZero)
+			if !errors.Is(err, want[name]) {
+				t.Errorf("expected error %v, got %v", want[name], err)
+			}
+		})
+	}
+}
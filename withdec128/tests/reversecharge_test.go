@@ -0,0 +1,177 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/money"
+	"github.com/profe-ajedrez/badassitron/withdec128"
+	"github.com/profe-ajedrez/badassitron/withdec128/handler"
+)
+
+func TestReverseCharge(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	taxes := []*withdec128.InputTax{
+		{CodeValue: "ILA", V: dec128.FromInt(10), Id: 1, Typee: withdec128.Amount},
+		{CodeValue: "IVA", V: dec128.FromInt(19), Id: 2, Stagee: withdec128.Overtax},
+		{CodeValue: "IGIC", V: dec128.FromInt(7), Id: 3, Stagee: withdec128.Bypass},
+	}
+
+	const mention = "Art. 196 Directive 2006/112/EC"
+
+	type testCase struct {
+		name     string
+		rc       *withdec128.ReverseCharge
+		exempts  withdec128.Exemptions
+		taxes    []*withdec128.InputTax
+		tax      string
+		gross    string
+		reverse  string
+		reversed map[string]bool
+		err      error
+	}
+
+	testCases := []testCase{
+		{
+			// 19% of 2000 + 20 = 383.8, 7% of 2000 = 140
+			"every percentual tax",
+			&withdec128.ReverseCharge{Mention: mention}, nil, taxes,
+			"20", "2020", "523.8",
+			map[string]bool{"IVA": true, "IGIC": true},
+			nil,
+		},
+		{
+			"by tax code",
+			&withdec128.ReverseCharge{TaxCodes: []string{"iva"}, Mention: mention}, nil, taxes,
+			"160", "2160", "383.8",
+			map[string]bool{"IVA": true},
+			nil,
+		},
+		{
+			"exempt tax is not reverse charged",
+			&withdec128.ReverseCharge{Mention: mention}, withdec128.Exemptions{{TaxCode: "IVA"}}, taxes,
+			"20", "2020", "140",
+			map[string]bool{"IGIC": true},
+			nil,
+		},
+		{
+			// 10% of 2000, the self-assessed 380 of VAT is not in its base
+			"natural under an overtax",
+			&withdec128.ReverseCharge{TaxCodes: []string{"IVA"}, Mention: mention}, nil,
+			[]*withdec128.InputTax{{CodeValue: "IVA", V: dec128.FromInt(19), Id: 1}, {CodeValue: "X", V: dec128.FromInt(10), Id: 2, Stagee: withdec128.Overtax}},
+			"200", "2200", "380",
+			map[string]bool{"IVA": true},
+			nil,
+		},
+		{
+			"invalid reverse charged tax",
+			&withdec128.ReverseCharge{Mention: mention}, nil,
+			[]*withdec128.InputTax{{CodeValue: "IVA", V: dec128.FromInt(119), Id: 1}},
+			"", "", "", nil,
+			withdec128.ErrTaxOver100,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opt := &withdec128.Options{DetailTaxProcess: withdec128.NewDetailTaxes(), Reverse: tc.rc, Exempts: tc.exempts}
+			input := &withdec128.Input{
+				UV:      dec128.FromInt(1000),
+				QTY:     dec128.FromInt(2),
+				TaxList: tc.taxes,
+			}
+			output := &withdec128.Output{}
+
+			err := handler.Next(opt, input, output, handler.EntryValidation, handler.Bootstrap, handler.Netter, handler.Taxer, handler.Grosser)
+
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected error %v, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if output.Tax().String() != tc.tax || output.Gross().String() != tc.gross {
+				t.Errorf("expected tax %s and gross %s, got %s and %s", tc.tax, tc.gross, output.Tax(), output.Gross())
+			}
+			if !output.ReverseCharged || output.LegalMention != mention || output.ReverseChargeTax.String() != tc.reverse {
+				t.Errorf("expected %s reverse charged with %q, got %v %s with %q",
+					tc.reverse, mention, output.ReverseCharged, output.ReverseChargeTax, output.LegalMention)
+			}
+
+			charged := dec128.Zero
+			for _, d := range output.DetailTaxes() {
+				if !tc.reversed[d.Code()] {
+					charged = charged.Add(d.Amount())
+				}
+				if got := d.(withdec128.ReverseChargeDetailer).ReverseCharged(); got != tc.reversed[d.Code()] {
+					t.Errorf("expected %s reverse charged to be %v, got %v", d.Code(), tc.reversed[d.Code()], got)
+				}
+				if tc.reversed[d.Code()] && (d.Amount().IsZero() || d.Taxable().IsZero()) {
+					t.Errorf("expected %s to be detailed, got %s on %s", d.Code(), d.Amount(), d.Taxable())
+				}
+			}
+			if !charged.Equal(output.Tax()) {
+				t.Errorf("expected the charged taxes to detail %s, got %s", output.Tax(), charged)
+			}
+		})
+	}
+}
+
+func TestWithoutReverseCharge(t *testing.T) {
+	opt := &withdec128.Options{DetailTaxProcess: withdec128.NewDetailTaxes()}
+	input := &withdec128.Input{
+		UV:      dec128.FromInt(1000),
+		QTY:     dec128.FromInt(2),
+		TaxList: []*withdec128.InputTax{{CodeValue: "IVA", V: dec128.FromInt(19), Id: 1}},
+	}
+	output := &withdec128.Output{}
+
+	if err := handler.Next(opt, input, output, handler.EntryValidation, handler.Bootstrap, handler.Netter, handler.Taxer, handler.Grosser); err != nil {
+		t.Fatal(err)
+	}
+
+	if output.ReverseCharged || output.Tax().String() != "380" {
+		t.Errorf("expected 380 of tax charged by the seller, got %s reverse charged %v", output.Tax(), output.ReverseCharged)
+	}
+}
+
+func TestConvertReverseCharge(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	const mention = "Art. 196 Directive 2006/112/EC"
+
+	opt := &withdec128.Options{DetailTaxProcess: withdec128.NewDetailTaxes(), Reverse: &withdec128.ReverseCharge{TaxCodes: []string{"IVA"}, Mention: mention}}
+	input := &withdec128.Input{
+		UV:  dec128.FromInt(1000),
+		QTY: dec128.FromInt(2),
+		TaxList: []*withdec128.InputTax{
+			{CodeValue: "ILA", V: dec128.FromInt(10), Id: 1, Typee: withdec128.Amount},
+			{CodeValue: "IVA", V: dec128.FromInt(19), Id: 2, Stagee: withdec128.Overtax},
+		},
+	}
+	output := &withdec128.Output{}
+
+	if err := handler.Next(opt, input, output, handler.EntryValidation, handler.Bootstrap, handler.Netter, handler.Taxer, handler.Grosser); err != nil {
+		t.Fatal(err)
+	}
+
+	rate := money.ExchangeRate{From: "CLP", To: "USD", Rate: dec128.FromString("0.001")}
+	ro := withdec128.ConvertOutput(output, rate, money.MustLookup("USD"))
+
+	// 383.8 CLP of IVA
+	if !ro.ReverseCharged || ro.LegalMention != mention || ro.ReverseChargeTax.String() != "0.38" {
+		t.Errorf("expected 0.38 reverse charged with %q, got %v %s with %q", mention, ro.ReverseCharged, ro.ReverseChargeTax, ro.LegalMention)
+	}
+
+	for _, d := range ro.DetailTaxes() {
+		if got := d.(withdec128.ReverseChargeDetailer).ReverseCharged(); got != (d.Code() == "IVA") {
+			t.Errorf("expected %s reverse charged to be %v, got %v", d.Code(), d.Code() == "IVA", got)
+		}
+	}
+}
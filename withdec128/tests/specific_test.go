@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/withdec128"
+	"github.com/profe-ajedrez/badassitron/withdec128/handler"
+)
+
+func TestSpecificTaxes(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	alcohol := &withdec128.InputTax{
+		CodeValue: "ILA",
+		V:         dec128.FromInt(10),
+		Id:        1,
+		Typee:     withdec128.Specific,
+		Stagee:    withdec128.Natural,
+		Formula:   withdec128.PerAttribute("volume", "degree"),
+	}
+	sugar := &withdec128.InputTax{
+		CodeValue: "SUGAR",
+		V:         dec128.FromInt(2),
+		Id:        2,
+		Typee:     withdec128.Specific,
+		Stagee:    withdec128.Bypass,
+		Formula:   withdec128.PerAttributeOver("sugar", dec128.FromString("6.25")),
+	}
+	vat := &withdec128.InputTax{CodeValue: "IVA", V: dec128.FromInt(19), Id: 3, Typee: withdec128.Percentual, Stagee: withdec128.Overtax}
+
+	type testCase struct {
+		name    string
+		attrs   withdec128.Attributes
+		taxes   []*withdec128.InputTax
+		tax     string
+		details map[string]string
+		err     error
+	}
+
+	testCases := []testCase{
+		{
+			// 2 * 10 * 0.5 * 5 = 50, and the vat applies on 2000 + 50
+			"alcohol in the overtax base",
+			withdec128.Attributes{"volume": dec128.FromString("0.5"), "degree": dec128.FromInt(5)},
+			[]*withdec128.InputTax{alcohol, vat},
+			"439.5",
+			map[string]string{"ILA": "50", "IVA": "389.5"},
+			nil,
+		},
+		{
+			// 2 * 2 * (10 - 6.25) = 15, outside the vat base
+			"sugar over the threshold",
+			withdec128.Attributes{"sugar": dec128.FromInt(10)},
+			[]*withdec128.InputTax{sugar, vat},
+			"395",
+			map[string]string{"SUGAR": "15", "IVA": "380"},
+			nil,
+		},
+		{
+			"sugar under the threshold",
+			withdec128.Attributes{"sugar": dec128.FromInt(5)},
+			[]*withdec128.InputTax{sugar},
+			"0",
+			map[string]string{"SUGAR": "0"},
+			nil,
+		},
+		{
+			"missing attribute",
+			withdec128.Attributes{"volume": dec128.FromString("0.5")},
+			[]*withdec128.InputTax{alcohol},
+			"", nil, withdec128.ErrMissingAttribute,
+		},
+		{
+			"negative attribute",
+			withdec128.Attributes{"volume": dec128.FromString("0.5"), "degree": dec128.FromInt(-5)},
+			[]*withdec128.InputTax{alcohol},
+			"", nil, withdec128.ErrInvalidAttribute,
+		},
+		{
+			"without formula",
+			nil,
+			[]*withdec128.InputTax{{V: dec128.FromInt(10), Id: 4, Typee: withdec128.Specific}},
+			"", nil, withdec128.ErrNoFormula,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opt := &withdec128.Options{DetailTaxProcess: withdec128.NewDetailTaxes()}
+			input := &withdec128.Input{
+				UV:      dec128.FromInt(1000),
+				QTY:     dec128.FromInt(2),
+				TaxList: tc.taxes,
+				Attrs:   tc.attrs,
+			}
+			output := &withdec128.Output{}
+
+			err := handler.Next(opt, input, output, handler.EntryValidation, handler.Bootstrap, handler.Netter, handler.Taxer, handler.Grosser)
+
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected error %v, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if output.Tax().String() != tc.tax {
+				t.Errorf("expected tax %s, got %s", tc.tax, output.Tax())
+			}
+
+			for _, d := range output.DetailTaxes() {
+				if want := tc.details[d.Code()]; d.Amount().String() != want {
+					t.Errorf("expected %s to be %s, got %s", d.Code(), want, d.Amount())
+				}
+				if d.Code() != "IVA" && d.Type() != withdec128.Specific {
+					t.Errorf("expected %s to be reported as specific, got type %d", d.Code(), d.Type())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/withdec128"
+	"github.com/profe-ajedrez/badassitron/withdec128/handler"
+)
+
+func TestTaxBases(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	type testCase struct {
+		name     string
+		taxes    []*withdec128.InputTax
+		tax      string
+		taxWD    string
+		details  map[string]string
+		taxables map[string]string
+		err      error
+	}
+
+	testCases := []testCase{
+		{
+			"discounted net",
+			[]*withdec128.InputTax{{CodeValue: "IVA", V: dec128.FromInt(19), Id: 1}},
+			"342", "380",
+			map[string]string{"IVA": "342"},
+			map[string]string{"IVA": "1800"},
+			nil,
+		},
+		{
+			"undiscounted net",
+			[]*withdec128.InputTax{{CodeValue: "IVA", V: dec128.FromInt(19), Id: 1, Basee: withdec128.BaseNetWD}},
+			"380", "380",
+			map[string]string{"IVA": "380"},
+			map[string]string{"IVA": "2000"},
+			nil,
+		},
+		{
+			"reference price",
+			[]*withdec128.InputTax{{CodeValue: "REF", V: dec128.FromInt(10), Id: 1, Stagee: withdec128.Bypass, Basee: withdec128.BaseReference, BaseV: dec128.FromInt(1200)}},
+			"240", "240",
+			map[string]string{"REF": "240"},
+			map[string]string{"REF": "2400"},
+			nil,
+		},
+		{
+			"fixed amount",
+			[]*withdec128.InputTax{{CodeValue: "FIX", V: dec128.FromInt(10), Id: 1, Basee: withdec128.BaseFixed, BaseV: dec128.FromInt(500)}},
+			"50", "50",
+			map[string]string{"FIX": "50"},
+			map[string]string{"FIX": "500"},
+			nil,
+		},
+		{
+			// 10% of 2000 = 200, and the vat applies on 1800 + 200
+			"undiscounted natural under an overtax",
+			[]*withdec128.InputTax{
+				{CodeValue: "LUX", V: dec128.FromInt(10), Id: 1, Basee: withdec128.BaseNetWD},
+				{CodeValue: "IVA", V: dec128.FromInt(19), Id: 2, Stagee: withdec128.Overtax},
+			},
+			"580", "618",
+			map[string]string{"LUX": "200", "IVA": "380"},
+			map[string]string{"LUX": "2000", "IVA": "1800"},
+			nil,
+		},
+		{
+			"unknown base",
+			[]*withdec128.InputTax{{V: dec128.FromInt(19), Id: 1, Basee: 7}},
+			"", "", nil, nil, withdec128.ErrInvalidTaxBase,
+		},
+		{
+			"negative base value",
+			[]*withdec128.InputTax{{V: dec128.FromInt(19), Id: 1, Basee: withdec128.BaseFixed, BaseV: dec128.FromInt(-1)}},
+			"", "", nil, nil, withdec128.ErrInvalidTaxBase,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opt := &withdec128.Options{DetailTaxProcess: withdec128.NewDetailTaxes()}
+			input := &withdec128.Input{
+				UV:      dec128.FromInt(1000),
+				QTY:     dec128.FromInt(2),
+				Disc:    dec128.FromInt(10),
+				TaxList: tc.taxes,
+			}
+			output := &withdec128.Output{}
+
+			err := handler.Next(opt, input, output, handler.EntryValidation, handler.Bootstrap, handler.Netter, handler.Taxer, handler.Grosser)
+
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected error %v, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if output.Tax().String() != tc.tax || output.TaxWD().String() != tc.taxWD {
+				t.Errorf("expected tax %s and %s before discounts, got %s and %s", tc.tax, tc.taxWD, output.Tax(), output.TaxWD())
+			}
+
+			for _, d := range output.DetailTaxes() {
+				if want := tc.details[d.Code()]; d.Amount().String() != want {
+					t.Errorf("expected %s to be %s, got %s", d.Code(), want, d.Amount())
+				}
+				if want := tc.taxables[d.Code()]; d.Taxable().String() != want {
+					t.Errorf("expected %s to apply on %s, got %s", d.Code(), want, d.Taxable())
+				}
+			}
+		})
+	}
+}
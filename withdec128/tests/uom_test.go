@@ -0,0 +1,185 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+	"github.com/profe-ajedrez/badassitron/withdec128"
+	"github.com/profe-ajedrez/badassitron/withdec128/handler"
+)
+
+func testUoMRegistry(t *testing.T) *withdec128.UoMRegistry {
+	t.Helper()
+
+	reg, err := withdec128.NewUoMRegistry(
+		withdec128.UnitOfMeasure{Code: "BOT", Base: "L", Factor: dec128.FromString("0.5")},
+		withdec128.UnitOfMeasure{Code: "BOX12", Base: "bot", Factor: dec128.FromInt(12)},
+		withdec128.UnitOfMeasure{Code: "PALLET", Base: "BOX12", Factor: dec128.FromInt(40)},
+		withdec128.UnitOfMeasure{Code: "KG", Base: "G", Factor: dec128.FromInt(1000)},
+		withdec128.UnitOfMeasure{Code: "PACK3", Base: "UN", Factor: dec128.FromInt(3)},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return reg
+}
+
+func TestUoMRegistry(t *testing.T) {
+	reg := testUoMRegistry(t)
+
+	type testCase struct {
+		code   string
+		base   string
+		factor string
+		err    error
+	}
+
+	testCases := []testCase{
+		{"BOT", "L", "0.5", nil},
+		{"box12", "L", "6", nil},
+		{"PALLET", "L", "240", nil},
+		{"L", "L", "1", nil},
+		{"KG", "G", "1000", nil},
+		{"M", "", "", withdec128.ErrUnknownUoM},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.code, func(t *testing.T) {
+			u, err := reg.Lookup(tc.code)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected error %v, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if u.Base != tc.base || u.Factor.String() != tc.factor {
+				t.Errorf("expected %s %s, got %s %s", tc.factor, tc.base, u.Factor, u.Base)
+			}
+		})
+	}
+
+	if q, err := reg.Convert(dec128.FromInt(2), "PALLET", "BOT"); err != nil || q.String() != "960" {
+		t.Errorf("expected 960 bottles, got %s (%v)", q, err)
+	}
+
+	if _, err := reg.Convert(dec128.FromInt(2), "KG", "BOT"); !errors.Is(err, withdec128.ErrUoMMismatch) {
+		t.Errorf("expected error %v, got %v", withdec128.ErrUoMMismatch, err)
+	}
+
+	invalid := []withdec128.UnitOfMeasure{
+		{Code: "L", Base: "PALLET", Factor: dec128.FromInt(1)},
+		{Code: "X", Base: "X", Factor: dec128.FromInt(1)},
+		{Code: "X", Base: "L", Factor: dec128.Zero},
+		{Code: "X", Base: "L", Factor: dec128.FromInt(-2)},
+		{Code: "", Base: "L", Factor: dec128.FromInt(2)},
+	}
+
+	for _, u := range invalid {
+		if err := reg.Add(u); !errors.Is(err, withdec128.ErrInvalidUoM) {
+			t.Errorf("%s of %s %s: expected error %v, got %v", u.Code, u.Factor, u.Base, withdec128.ErrInvalidUoM, err)
+		}
+	}
+}
+
+func TestUoMNormalizer(t *testing.T) {
+	dec128.SetDefaultPrecision(19)
+
+	reg := testUoMRegistry(t)
+
+	type testCase struct {
+		name   string
+		uom    string
+		qty    string
+		uv     string
+		taxes  []*withdec128.InputTax
+		bqty   string
+		buv    string
+		net    string
+		tax    string
+		base   string
+		detail bool
+		err    error
+	}
+
+	perLiter := []*withdec128.InputTax{{V: dec128.FromString("0.1"), Typee: withdec128.Amount, Id: 1}}
+
+	testCases := []testCase{
+		{"boxes taxed per liter", "BOX12", "2", "30", perLiter, "12", "5", "60", "1.2", "L", true, nil},
+		{"pallet", "PALLET", "1", "1200", perLiter, "240", "5", "1200", "24", "L", true, nil},
+		{"inexact unit value", "PACK3", "1", "10", nil, "3", "3.3333333333333333333", "10", "0", "UN", true, nil},
+		{"base unit", "L", "3", "5", perLiter, "3", "5", "15", "0.3", "L", true, nil},
+		{"no unit", "", "3", "5", perLiter, "3", "5", "15", "0.3", "", false, nil},
+		{"unknown unit", "M", "3", "5", nil, "", "", "", "", "", false, withdec128.ErrUnknownUoM},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opt := &withdec128.Options{DetailTaxProcess: withdec128.NewDetailTaxes()}
+			input := &withdec128.Input{
+				UV:      dec128.FromString(tc.uv),
+				QTY:     dec128.FromString(tc.qty),
+				UoM:     tc.uom,
+				TaxList: tc.taxes,
+			}
+			output := &withdec128.Output{}
+
+			err := handler.Next(
+				opt, input, output,
+				handler.EntryValidation,
+				handler.UoMNormalizer(reg),
+				handler.Bootstrap,
+				handler.Netter,
+				handler.Taxer,
+				handler.Grosser,
+			)
+
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected error %v, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if output.Qty().String() != tc.bqty || output.Unitary().String() != tc.buv {
+				t.Errorf("expected %s at %s per base unit, got %s at %s", tc.bqty, tc.buv, output.Qty(), output.Unitary())
+			}
+			if output.Net().String() != tc.net {
+				t.Errorf("expected net %s, got %s", tc.net, output.Net())
+			}
+			if output.Tax().String() != tc.tax {
+				t.Errorf("expected tax %s, got %s", tc.tax, output.Tax())
+			}
+
+			if !tc.detail {
+				if output.Units != nil {
+					t.Errorf("expected no unit detail, got %+v", output.Units)
+				}
+				return
+			}
+
+			ud := output.Units
+			if ud == nil {
+				t.Fatal("expected a unit detail")
+			}
+			if ud.SoldQty.String() != tc.qty || ud.SoldUnitValue.String() != tc.uv || ud.BaseUnit != tc.base {
+				t.Errorf("expected %s %s at %s in %s, got %s %s at %s in %s",
+					tc.qty, tc.uom, tc.uv, tc.base, ud.SoldQty, ud.SoldUnit, ud.SoldUnitValue, ud.BaseUnit)
+			}
+			if ud.BaseQty.String() != tc.bqty || ud.BaseUnitValue.String() != tc.buv {
+				t.Errorf("expected %s %s at %s, got %s at %s", tc.bqty, tc.base, tc.buv, ud.BaseQty, ud.BaseUnitValue)
+			}
+			if input.QTY.String() != tc.qty || input.UV.String() != tc.uv || input.UnitOfMeasure() != tc.uom {
+				t.Errorf("expected the input to keep %s %s at %s, got %s %s at %s", tc.qty, tc.uom, tc.uv, input.QTY, input.UoM, input.UV)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package withdec128
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/profe-ajedrez/badassitron/dec128"
+)
+
+// UnitOfMeasure is a unit in which quantities are sold, holding Factor units of Base.
+// For example, a box of 12 bottles of half a liter is {Code: "BOX12", Base: "L", Factor: 6}.
+type UnitOfMeasure struct {
+	Code   string        // Unit code, e.g. "BOX12"
+	Base   string        // Code of the unit it is made of, e.g. "L"
+	Factor dec128.Dec128 // Units of Base in one unit
+}
+
+// UoMRegistry holds units of measure by code. A unit may be made of another registered unit,
+// e.g. a box made of packs made of liters, and every unit is resolved to the base unit at the end of the chain.
+// Base units need no registration. Codes are case insensitive.
+// It is safe for concurrent use.
+type UoMRegistry struct {
+	mu    sync.RWMutex
+	units map[string]UnitOfMeasure
+}
+
+// NewUoMRegistry creates a UoMRegistry holding units.
+func NewUoMRegistry(units ...UnitOfMeasure) (*UoMRegistry, error) {
+	r := &UoMRegistry{units: make(map[string]UnitOfMeasure)}
+
+	for _, u := range units {
+		if err := r.Add(u); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// Add registers u, replacing the unit with the same code.
+// The factor must be positive, and u can't be made of itself, directly or through other units.
+func (r *UoMRegistry) Add(u UnitOfMeasure) error {
+	u.Code = strings.ToUpper(u.Code)
+	u.Base = strings.ToUpper(u.Base)
+
+	if u.Code == "" || u.Base == "" {
+		return ErrInvalidUoM
+	}
+
+	if u.Factor.IsNaN() || !u.Factor.IsPositive() {
+		return ErrInvalidUoM
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for base := u.Base; ; {
+		if base == u.Code {
+			return ErrInvalidUoM
+		}
+
+		next, ok := r.units[base]
+		if !ok {
+			break
+		}
+		base = next.Base
+	}
+
+	r.units[u.Code] = u
+
+	return nil
+}
+
+// Lookup returns the unit with code resolved to its base unit, with the factor of the whole chain.
+// A code not registered is taken as a base unit if some registered unit is made of it.
+func (r *UoMRegistry) Lookup(code string) (UnitOfMeasure, error) {
+	code = strings.ToUpper(code)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	u, ok := r.units[code]
+	if !ok {
+		if r.isBase(code) {
+			return UnitOfMeasure{Code: code, Base: code, Factor: One()}, nil
+		}
+		return UnitOfMeasure{}, ErrUnknownUoM
+	}
+
+	for {
+		next, ok := r.units[u.Base]
+		if !ok {
+			return u, nil
+		}
+		u.Base = next.Base
+		u.Factor = u.Factor.Mul(next.Factor)
+	}
+}
+
+// Convert returns qty units of from expressed in units of to. Both units must share the same base unit.
+func (r *UoMRegistry) Convert(qty dec128.Dec128, from, to string) (dec128.Dec128, error) {
+	f, err := r.Lookup(from)
+	if err != nil {
+		return dec128.Dec128{}, err
+	}
+
+	t, err := r.Lookup(to)
+	if err != nil {
+		return dec128.Dec128{}, err
+	}
+
+	if f.Base != t.Base {
+		return dec128.Dec128{}, ErrUoMMismatch
+	}
+
+	return qty.Mul(f.Factor).Div(t.Factor), nil
+}
+
+func (r *UoMRegistry) isBase(code string) bool {
+	for _, u := range r.units {
+		if u.Base == code {
+			return true
+		}
+	}
+	return false
+}
+
+// UnitDetail tells the unit of measure a line was sold in, and the base unit it was calculated in.
+type UnitDetail struct {
+	SoldUnit      string        // Unit the quantity and unit value of the input were given in
+	SoldQty       dec128.Dec128 // Quantity in SoldUnit
+	SoldUnitValue dec128.Dec128 // Unit value per SoldUnit
+	BaseUnit      string        // Unit the calculation was made in
+	BaseQty       dec128.Dec128 // Quantity in BaseUnit
+	BaseUnitValue dec128.Dec128 // Unit value per BaseUnit, rounded at the default precision
+	Factor        dec128.Dec128 // Units of BaseUnit in one SoldUnit
+}